@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesisanalyticsv2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+// dataSourceAwsKinesisAnalyticsV2Application mirrors the computed attributes
+// of resourceAwsKinesisAnalyticsV2Application so the two stay in sync; it
+// reuses the resource's schema (and flatteners) rather than redeclaring them.
+func dataSourceAwsKinesisAnalyticsV2Application() *schema.Resource {
+	dsSchema := datasourceSchemaFromResourceSchema(resourceAwsKinesisAnalyticsV2Application().Schema)
+
+	dsSchema["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	dsSchema["runtime_environment"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceAwsKinesisAnalyticsV2ApplicationRead,
+		Schema: dsSchema,
+	}
+}
+
+func dataSourceAwsKinesisAnalyticsV2ApplicationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kinesisanalyticsv2conn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+	name := d.Get("name").(string)
+
+	resp, err := conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+		ApplicationName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("error reading Kinesis Analytics Application (%s): %s", name, err)
+	}
+
+	detail := resp.ApplicationDetail
+	arn := aws.StringValue(detail.ApplicationARN)
+
+	d.SetId(arn)
+	d.Set("name", aws.StringValue(detail.ApplicationName))
+	d.Set("arn", arn)
+	d.Set("runtime", aws.StringValue(detail.RuntimeEnvironment))
+	d.Set("runtime_environment", aws.StringValue(detail.RuntimeEnvironment))
+	d.Set("service_execution_role", aws.StringValue(detail.ServiceExecutionRole))
+	d.Set("status", aws.StringValue(detail.ApplicationStatus))
+	d.Set("version", int(aws.Int64Value(detail.ApplicationVersionId)))
+	d.Set("create_timestamp", aws.TimeValue(detail.CreateTimestamp).Format(time.RFC3339))
+	d.Set("last_update_timestamp", aws.TimeValue(detail.LastUpdateTimestamp).Format(time.RFC3339))
+
+	if err := d.Set("cloudwatch_logging_options", flattenKinesisAnalyticsV2CloudwatchLoggingOptions(detail.CloudWatchLoggingOptionDescriptions)); err != nil {
+		return fmt.Errorf("error setting cloudwatch_logging_options: %s", err)
+	}
+
+	if detail.ApplicationConfigurationDescription.EnvironmentPropertyDescriptions != nil {
+		if err := d.Set("property_groups", flattenKinesisAnalyticsPropertyGroups(detail.ApplicationConfigurationDescription.EnvironmentPropertyDescriptions.PropertyGroupDescriptions)); err != nil {
+			return fmt.Errorf("error setting property_groups: %s", err)
+		}
+	}
+
+	runtime := aws.StringValue(detail.RuntimeEnvironment)
+	if runtime == kinesisanalyticsv2.RuntimeEnvironmentSql10 {
+		if err := d.Set("sql_application_configuration", flattenSqlApplicationConfigurationDescription(detail.ApplicationConfigurationDescription.SqlApplicationConfigurationDescription)); err != nil {
+			return fmt.Errorf("error setting sql_application_configuration: %s", err)
+		}
+	}
+	if runtimeIsFlink(runtime) {
+		if err := d.Set("flink_application_configuration", flattenFlinkApplicationConfigurationDescription(detail.ApplicationConfigurationDescription.FlinkApplicationConfigurationDescription, detail.ApplicationConfigurationDescription.ApplicationCodeConfigurationDescription)); err != nil {
+			return fmt.Errorf("error setting flink_application_configuration: %s", err)
+		}
+	}
+	if runtimeIsZeppelin(runtime) {
+		if err := d.Set("zeppelin_application_configuration", flattenZeppelinApplicationConfigurationDescription(detail.ApplicationConfigurationDescription.ZeppelinApplicationConfigurationDescription, detail.ApplicationConfigurationDescription.ApplicationCodeConfigurationDescription)); err != nil {
+			return fmt.Errorf("error setting zeppelin_application_configuration: %s", err)
+		}
+	}
+
+	tags, err := keyvaluetags.Kinesisanalyticsv2ListTags(conn, arn)
+	if err != nil {
+		return fmt.Errorf("error listing tags for Kinesis Analytics Application (%s): %s", arn, err)
+	}
+
+	if err := d.Set("tags", tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
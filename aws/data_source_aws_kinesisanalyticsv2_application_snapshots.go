@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesisanalyticsv2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// This data source lists the snapshots of an application
+// (kinesisanalyticsv2.ListApplicationSnapshots); it's a read-only
+// counterpart to the aws_kinesisanalyticsv2_application_snapshot managed
+// resource and isn't part of the application resource's restore/waiter
+// behavior, which is tracked separately in
+// resource_aws_kinesis_analyticsv2_application.go.
+func dataSourceAwsKinesisAnalyticsV2ApplicationSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsKinesisAnalyticsV2ApplicationSnapshotsRead,
+
+		Schema: map[string]*schema.Schema{
+			"application_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"snapshot_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAwsKinesisAnalyticsV2ApplicationSnapshotsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kinesisanalyticsv2conn
+	applicationName := d.Get("application_name").(string)
+
+	var snapshotNames []string
+	input := &kinesisanalyticsv2.ListApplicationSnapshotsInput{
+		ApplicationName: aws.String(applicationName),
+	}
+	err := conn.ListApplicationSnapshotsPages(input, func(page *kinesisanalyticsv2.ListApplicationSnapshotsOutput, lastPage bool) bool {
+		for _, s := range page.SnapshotSummaries {
+			snapshotNames = append(snapshotNames, aws.StringValue(s.SnapshotName))
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return fmt.Errorf("error listing Kinesis Analytics Application Snapshots (%s): %s", applicationName, err)
+	}
+
+	d.SetId(applicationName)
+	d.Set("application_name", applicationName)
+	if err := d.Set("snapshot_names", snapshotNames); err != nil {
+		return fmt.Errorf("error setting snapshot_names: %s", err)
+	}
+
+	return nil
+}
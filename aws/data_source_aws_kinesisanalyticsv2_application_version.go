@@ -0,0 +1,159 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesisanalyticsv2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// dataSourceAwsKinesisAnalyticsV2ApplicationVersion exposes a single
+// historical ApplicationVersionDetail (via DescribeApplicationVersion) so
+// operators can pin a deployment to, or diff against, a prior version rather
+// than whatever the application currently reports.
+func dataSourceAwsKinesisAnalyticsV2ApplicationVersion() *schema.Resource {
+	// Reuse the resource's own runtime-specific configuration blocks (rather
+	// than redeclaring them here) so a historical version can actually be
+	// diffed against the application's current code/runtime configuration,
+	// the same way dataSourceAwsKinesisAnalyticsV2Application does.
+	resourceSchema := datasourceSchemaFromResourceSchema(resourceAwsKinesisAnalyticsV2Application().Schema)
+
+	return &schema.Resource{
+		Read: dataSourceAwsKinesisAnalyticsV2ApplicationVersionRead,
+
+		Schema: map[string]*schema.Schema{
+			"application_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"application_version_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"runtime": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"version_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_timestamp": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cloudwatch_logging_options": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"log_stream_arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"property_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"property_map": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"sql_application_configuration":      resourceSchema["sql_application_configuration"],
+			"flink_application_configuration":    resourceSchema["flink_application_configuration"],
+			"zeppelin_application_configuration": resourceSchema["zeppelin_application_configuration"],
+		},
+	}
+}
+
+func dataSourceAwsKinesisAnalyticsV2ApplicationVersionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kinesisanalyticsv2conn
+	applicationName := d.Get("application_name").(string)
+	applicationVersionId := int64(d.Get("application_version_id").(int))
+
+	resp, err := conn.DescribeApplicationVersion(&kinesisanalyticsv2.DescribeApplicationVersionInput{
+		ApplicationName:      aws.String(applicationName),
+		ApplicationVersionId: aws.Int64(applicationVersionId),
+	})
+	if err != nil {
+		return fmt.Errorf("error reading Kinesis Analytics Application (%s) version %d: %s", applicationName, applicationVersionId, err)
+	}
+
+	detail := resp.ApplicationVersionDetail
+	d.SetId(fmt.Sprintf("%s/%d", applicationName, applicationVersionId))
+	d.Set("application_name", applicationName)
+	d.Set("application_version_id", int(aws.Int64Value(detail.ApplicationVersionId)))
+	d.Set("arn", aws.StringValue(detail.ApplicationARN))
+	d.Set("runtime", aws.StringValue(detail.RuntimeEnvironment))
+	d.Set("status", aws.StringValue(detail.ApplicationStatus))
+	d.Set("version_description", aws.StringValue(detail.ApplicationDescription))
+	d.Set("create_timestamp", aws.TimeValue(detail.CreateTimestamp).Format(time.RFC3339))
+
+	if err := d.Set("cloudwatch_logging_options", flattenKinesisAnalyticsV2CloudwatchLoggingOptions(detail.CloudWatchLoggingOptionDescriptions)); err != nil {
+		return fmt.Errorf("error setting cloudwatch_logging_options: %s", err)
+	}
+
+	if detail.ApplicationConfigurationDescription.EnvironmentPropertyDescriptions != nil {
+		if err := d.Set("property_groups", flattenKinesisAnalyticsPropertyGroups(detail.ApplicationConfigurationDescription.EnvironmentPropertyDescriptions.PropertyGroupDescriptions)); err != nil {
+			return fmt.Errorf("error setting property_groups: %s", err)
+		}
+	}
+
+	runtime := aws.StringValue(detail.RuntimeEnvironment)
+	if runtime == kinesisanalyticsv2.RuntimeEnvironmentSql10 {
+		if err := d.Set("sql_application_configuration", flattenSqlApplicationConfigurationDescription(detail.ApplicationConfigurationDescription.SqlApplicationConfigurationDescription)); err != nil {
+			return fmt.Errorf("error setting sql_application_configuration: %s", err)
+		}
+	}
+	if runtimeIsFlink(runtime) {
+		if err := d.Set("flink_application_configuration", flattenFlinkApplicationConfigurationDescription(detail.ApplicationConfigurationDescription.FlinkApplicationConfigurationDescription, detail.ApplicationConfigurationDescription.ApplicationCodeConfigurationDescription)); err != nil {
+			return fmt.Errorf("error setting flink_application_configuration: %s", err)
+		}
+	}
+	if runtimeIsZeppelin(runtime) {
+		if err := d.Set("zeppelin_application_configuration", flattenZeppelinApplicationConfigurationDescription(detail.ApplicationConfigurationDescription.ZeppelinApplicationConfigurationDescription, detail.ApplicationConfigurationDescription.ApplicationCodeConfigurationDescription)); err != nil {
+			return fmt.Errorf("error setting zeppelin_application_configuration: %s", err)
+		}
+	}
+
+	return nil
+}
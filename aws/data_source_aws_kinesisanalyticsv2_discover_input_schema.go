@@ -0,0 +1,326 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesisanalyticsv2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// dataSourceAwsKinesisAnalyticsV2DiscoverInputSchema wraps DiscoverInputSchema
+// so practitioners don't have to hand-author the schema block that
+// expandKinesisAnalyticsV2InputUpdate (and sql_application_configuration's
+// inputs.schema) consumes; its computed attributes are shaped identically to
+// that schema block.
+func dataSourceAwsKinesisAnalyticsV2DiscoverInputSchema() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsKinesisAnalyticsV2DiscoverInputSchemaRead,
+
+		Schema: map[string]*schema.Schema{
+			"service_execution_role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"kinesis_stream_arn": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateArn,
+				ConflictsWith: []string{"kinesis_firehose_arn", "s3"},
+			},
+
+			"kinesis_firehose_arn": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateArn,
+				ConflictsWith: []string{"kinesis_stream_arn", "s3"},
+			},
+
+			"s3": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"kinesis_stream_arn", "kinesis_firehose_arn"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket_arn": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateArn,
+						},
+
+						"file_key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"input_starting_position_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"starting_position": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								kinesisanalyticsv2.InputStartingPositionNow,
+								kinesisanalyticsv2.InputStartingPositionTrimHorizon,
+								kinesisanalyticsv2.InputStartingPositionLastStoppedPoint,
+							}, false),
+						},
+					},
+				},
+			},
+
+			"input_processing_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"lambda": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"resource_arn": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateArn,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"record_columns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mapping": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"sql_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"record_encoding": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"record_format": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"record_format_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"mapping_parameters": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"csv": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"record_column_delimiter": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+
+												"record_row_delimiter": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+
+									"json": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"record_row_path": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"raw_input_records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"parsed_input_records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeList,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAwsKinesisAnalyticsV2DiscoverInputSchemaRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kinesisanalyticsv2conn
+
+	input := &kinesisanalyticsv2.DiscoverInputSchemaInput{
+		ServiceExecutionRole: aws.String(d.Get("service_execution_role_arn").(string)),
+	}
+
+	switch {
+	case d.Get("kinesis_stream_arn").(string) != "":
+		input.ResourceARN = aws.String(d.Get("kinesis_stream_arn").(string))
+	case d.Get("kinesis_firehose_arn").(string) != "":
+		input.ResourceARN = aws.String(d.Get("kinesis_firehose_arn").(string))
+	case len(d.Get("s3").([]interface{})) > 0:
+		s3 := d.Get("s3").([]interface{})[0].(map[string]interface{})
+		input.S3Configuration = &kinesisanalyticsv2.S3Configuration{
+			BucketARN: aws.String(s3["bucket_arn"].(string)),
+			FileKey:   aws.String(s3["file_key"].(string)),
+		}
+	default:
+		return fmt.Errorf("one of kinesis_stream_arn, kinesis_firehose_arn, or s3 is required")
+	}
+
+	if v, ok := d.GetOk("input_starting_position_configuration"); ok && len(v.([]interface{})) > 0 {
+		spc := v.([]interface{})[0].(map[string]interface{})
+		input.InputStartingPositionConfiguration = &kinesisanalyticsv2.InputStartingPositionConfiguration{
+			InputStartingPosition: aws.String(spc["starting_position"].(string)),
+		}
+	}
+
+	if v, ok := d.GetOk("input_processing_configuration"); ok && len(v.([]interface{})) > 0 {
+		ipc := v.([]interface{})[0].(map[string]interface{})
+		l := ipc["lambda"].([]interface{})[0].(map[string]interface{})
+		input.InputProcessingConfiguration = &kinesisanalyticsv2.InputProcessingConfiguration{
+			InputLambdaProcessor: &kinesisanalyticsv2.InputLambdaProcessor{
+				ResourceARN: aws.String(l["resource_arn"].(string)),
+			},
+		}
+	}
+
+	resp, err := conn.DiscoverInputSchema(input)
+	if err != nil {
+		return fmt.Errorf("error discovering Kinesis Analytics input schema: %s", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	if resp.InputSchema != nil {
+		d.Set("record_encoding", aws.StringValue(resp.InputSchema.RecordEncoding))
+
+		var rcs []interface{}
+		for _, rc := range resp.InputSchema.RecordColumns {
+			rcs = append(rcs, map[string]interface{}{
+				"mapping":  aws.StringValue(rc.Mapping),
+				"name":     aws.StringValue(rc.Name),
+				"sql_type": aws.StringValue(rc.SqlType),
+			})
+		}
+		if err := d.Set("record_columns", rcs); err != nil {
+			return fmt.Errorf("error setting record_columns: %s", err)
+		}
+
+		if err := d.Set("record_format", flattenKinesisAnalyticsV2DiscoveredRecordFormat(resp.InputSchema.RecordFormat)); err != nil {
+			return fmt.Errorf("error setting record_format: %s", err)
+		}
+	}
+
+	var rawInputRecords []string
+	for _, r := range resp.RawInputRecords {
+		rawInputRecords = append(rawInputRecords, aws.StringValue(r))
+	}
+	d.Set("raw_input_records", rawInputRecords)
+
+	var parsedInputRecords [][]string
+	for _, pr := range resp.ParsedInputRecords {
+		var record []string
+		for _, f := range pr {
+			record = append(record, aws.StringValue(f))
+		}
+		parsedInputRecords = append(parsedInputRecords, record)
+	}
+	if err := d.Set("parsed_input_records", parsedInputRecords); err != nil {
+		return fmt.Errorf("error setting parsed_input_records: %s", err)
+	}
+
+	return nil
+}
+
+func flattenKinesisAnalyticsV2DiscoveredRecordFormat(rf *kinesisanalyticsv2.RecordFormat) []interface{} {
+	if rf == nil {
+		return []interface{}{}
+	}
+
+	rfM := map[string]interface{}{
+		"record_format_type": aws.StringValue(rf.RecordFormatType),
+	}
+
+	if rf.MappingParameters != nil {
+		var mps []interface{}
+		if rf.MappingParameters.CSVMappingParameters != nil {
+			mps = append(mps, map[string]interface{}{
+				"csv": []interface{}{
+					map[string]interface{}{
+						"record_column_delimiter": aws.StringValue(rf.MappingParameters.CSVMappingParameters.RecordColumnDelimiter),
+						"record_row_delimiter":    aws.StringValue(rf.MappingParameters.CSVMappingParameters.RecordRowDelimiter),
+					},
+				},
+			})
+		}
+		if rf.MappingParameters.JSONMappingParameters != nil {
+			mps = append(mps, map[string]interface{}{
+				"json": []interface{}{
+					map[string]interface{}{
+						"record_row_path": aws.StringValue(rf.MappingParameters.JSONMappingParameters.RecordRowPath),
+					},
+				},
+			})
+		}
+		rfM["mapping_parameters"] = mps
+	}
+
+	return []interface{}{rfM}
+}
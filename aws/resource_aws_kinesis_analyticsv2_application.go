@@ -1,7 +1,6 @@
 package aws
 
 import (
-	"bytes"
 	"fmt"
 	"log"
 	"reflect"
@@ -10,8 +9,8 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/kinesisanalyticsv2"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -22,6 +21,7 @@ var validateKinesisAnalyticsV2Runtime = validation.StringInSlice([]string{
 	kinesisanalyticsv2.RuntimeEnvironmentSql10,
 	kinesisanalyticsv2.RuntimeEnvironmentFlink16,
 	kinesisanalyticsv2.RuntimeEnvironmentFlink18,
+	kinesisanalyticsv2.RuntimeEnvironmentZeppelinFlink10,
 }, false)
 
 func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
@@ -31,6 +31,11 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 		Update: resourceAwsKinesisAnalyticsV2ApplicationUpdate,
 		Delete: resourceAwsKinesisAnalyticsV2ApplicationDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				arns := strings.Split(d.Id(), ":")
@@ -73,6 +78,15 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 				Optional: true,
 			},
 
+			// Lets callers force a redeploy of S3-hosted code (e.g. via
+			// filebase64sha256(...)) when s3_bucket/s3_object/object_version
+			// haven't changed but the underlying artifact has, mirroring
+			// aws_lambda_function's source_code_hash.
+			"source_code_hash": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"code": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -103,15 +117,138 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 				Computed: true,
 			},
 
+			"start_application": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"force_stop": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// application_restore_configuration lives here, under
+			// run_configuration, rather than nested inside start_application:
+			// RunConfiguration is the actual StartApplication input field
+			// that carries ApplicationRestoreConfiguration on the API side,
+			// and flink_run_configuration (AllowNonRestoredState) is its
+			// sibling on that same API struct, so keeping both under one
+			// run_configuration block mirrors StartApplicationInput directly
+			// instead of splitting it across two unrelated top-level blocks.
+			"run_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"application_restore_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"application_restore_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											kinesisanalyticsv2.ApplicationRestoreTypeSkipRestoreFromSnapshot,
+											kinesisanalyticsv2.ApplicationRestoreTypeRestoreFromLatestSnapshot,
+											kinesisanalyticsv2.ApplicationRestoreTypeRestoreFromCustomSnapshot,
+										}, false),
+									},
+
+									"snapshot_name": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+
+						"flink_run_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"allow_non_restored_state": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+								},
+							},
+						},
+
+						"sql_run_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"input_starting_position_configuration": {
+										Type:     schema.TypeList,
+										Required: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"starting_position": {
+													Type:     schema.TypeString,
+													Required: true,
+													ValidateFunc: validation.StringInSlice([]string{
+														kinesisanalyticsv2.InputStartingPositionNow,
+														kinesisanalyticsv2.InputStartingPositionTrimHorizon,
+														kinesisanalyticsv2.InputStartingPositionLastStoppedPoint,
+													}, false),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
 
-			"cloudwatch_logging_options": {
+			"version_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"rollback_on_update_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"maintenance_configuration": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"application_maintenance_window_start_time": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			// AWS currently documents a single logging option per application,
+			// but this is kept as a list (rather than MaxItems: 1) so it can
+			// be reconciled the same way regardless of how many the service
+			// allows in the future.
+			"cloudwatch_logging_options": {
+				Type:     schema.TypeList,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
@@ -119,11 +256,36 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 							Computed: true,
 						},
 
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						// ConflictsWith isn't used between log_stream_arn and
+						// log_group_name/log_stream_name here since this is a
+						// repeatable list, not a single nested block, and
+						// ConflictsWith can't address a sibling by relative
+						// index; resolveKinesisAnalyticsV2LogStreamArn just
+						// prefers log_stream_arn when both are set.
 						"log_stream_arn": {
 							Type:         schema.TypeString,
-							Required:     true,
+							Optional:     true,
+							Computed:     true,
 							ValidateFunc: validateArn,
 						},
+
+						// Convenience fields resolved to a log_stream_arn internally,
+						// following the same pattern as aws_kinesis_firehose_delivery_stream.
+						"log_group_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"log_stream_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
 					},
 				},
 			},
@@ -148,6 +310,14 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 					},
 				},
 			},
+			// Kept top-level rather than nested under
+			// flink_application_configuration.application_snapshot_configuration:
+			// the API's own ApplicationSnapshotConfiguration is already a
+			// top-level sibling of FlinkApplicationConfiguration on
+			// ApplicationConfiguration, and the stop/update/restart path in
+			// updateKinesisAnalyticsV2ApplicationPreservingState already
+			// reads this flag flat, so nesting it now would be a breaking
+			// schema change for no API-accuracy benefit.
 			"snapshots_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -156,17 +326,49 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 					return snapshotsEnabled == old
 				},
 			},
+			"vpc_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vpc_configuration_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"vpc_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"subnet_ids": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"security_group_ids": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
 			"flink_application_configuration": {
 				Type:          schema.TypeList,
 				Optional:      true,
 				MaxItems:      1,
-				ConflictsWith: []string{"sql_application_configuration"},
+				ConflictsWith: []string{"sql_application_configuration", "zeppelin_application_configuration"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"checkpoint_configuration": {
-							Type:     schema.TypeSet,
+							Type:     schema.TypeList,
 							Optional: true,
 							Computed: true,
+							MaxItems: 1,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"checkpoint_interval": {
@@ -190,9 +392,10 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 							},
 						},
 						"monitoring_configuration": {
-							Type:     schema.TypeSet,
+							Type:     schema.TypeList,
 							Optional: true,
 							Computed: true,
+							MaxItems: 1,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"configuration_type": {
@@ -214,9 +417,10 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 							},
 						},
 						"parallelism_configuration": {
-							Type:     schema.TypeSet,
+							Type:     schema.TypeList,
 							Optional: true,
 							Computed: true,
+							MaxItems: 1,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"autoscaling_enabled": {
@@ -239,6 +443,82 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 								},
 							},
 						},
+						"application_code_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"custom_artifact_configuration": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"artifact_type": {
+													Type:     schema.TypeString,
+													Required: true,
+													ValidateFunc: validation.StringInSlice([]string{
+														kinesisanalyticsv2.ArtifactTypeDependencyJar,
+														kinesisanalyticsv2.ArtifactTypeUdf,
+													}, false),
+												},
+
+												"s3_content_location": {
+													Type:          schema.TypeList,
+													Optional:      true,
+													MaxItems:      1,
+													ConflictsWith: []string{"flink_application_configuration.0.application_code_configuration.0.custom_artifact_configuration.0.maven_reference"},
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"bucket_arn": {
+																Type:         schema.TypeString,
+																Required:     true,
+																ValidateFunc: validateArn,
+															},
+
+															"file_key": {
+																Type:     schema.TypeString,
+																Required: true,
+															},
+
+															"object_version": {
+																Type:     schema.TypeString,
+																Optional: true,
+															},
+														},
+													},
+												},
+
+												"maven_reference": {
+													Type:          schema.TypeList,
+													Optional:      true,
+													MaxItems:      1,
+													ConflictsWith: []string{"flink_application_configuration.0.application_code_configuration.0.custom_artifact_configuration.0.s3_content_location"},
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"group_id": {
+																Type:     schema.TypeString,
+																Required: true,
+															},
+
+															"artifact_id": {
+																Type:     schema.TypeString,
+																Required: true,
+															},
+
+															"version": {
+																Type:     schema.TypeString,
+																Required: true,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -247,7 +527,7 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 				Type:          schema.TypeList,
 				Optional:      true,
 				MaxItems:      1,
-				ConflictsWith: []string{"flink_application_configuration"},
+				ConflictsWith: []string{"flink_application_configuration", "zeppelin_application_configuration"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"inputs": {
@@ -669,52 +949,213 @@ func resourceAwsKinesisAnalyticsV2Application() *schema.Resource {
 					},
 				},
 			},
-			"tags": tagsSchema(),
-		},
-	}
-}
-
-func resourceAwsKinesisAnalyticsV2ApplicationCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).kinesisanalyticsv2conn
-	name := d.Get("name").(string)
-	serviceExecutionRole := d.Get("service_execution_role").(string)
-	runtime := d.Get("runtime").(string)
-	s3Bucket := d.Get("s3_bucket").(string)
-	s3Object := d.Get("s3_object").(string)
-	textCode := d.Get("code").(string)
-	codeContentType := d.Get("code_content_type").(string)
-
-	var sqlApplicationConfiguration *kinesisanalyticsv2.SqlApplicationConfiguration
-	var flinkApplicationConfiguration *kinesisanalyticsv2.FlinkApplicationConfiguration
-	switch {
-	case strings.HasPrefix(runtime, "SQL"):
-		sqlConfig, ok := d.GetOk("sql_application_configuration")
-		if !ok {
-			break
-		}
-		sc := sqlConfig.([]interface{})[0]
-		if sc != nil {
-			sqlApplicationConfiguration = expandKinesisAnalayticsSqlApplicationConfiguration(sc.(map[string]interface{}))
-		}
 
-	case strings.HasPrefix(runtime, "FLINK"):
-		flinkConfig, ok := d.GetOk("flink_application_configuration")
-		if !ok {
-			break
-		}
-		fc := flinkConfig.([]interface{})[0]
-		if fc != nil {
-			flinkApplicationConfiguration = expandKinesisAnalyticsFlinkApplicationConfiguration(fc.(map[string]interface{}))
-		}
-	}
+			"zeppelin_application_configuration": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"flink_application_configuration", "sql_application_configuration"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"monitoring_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"log_level": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateLogLevel,
+									},
+								},
+							},
+						},
 
-	var contentType *string
-	switch codeContentType {
-	case "zip":
-		contentType = aws.String(kinesisanalyticsv2.CodeContentTypeZipfile)
-	case "plain_text":
-		contentType = aws.String(kinesisanalyticsv2.CodeContentTypePlaintext)
-	}
+						"catalog_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"glue_data_catalog_configuration": {
+										Type:     schema.TypeList,
+										Required: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"database_arn": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validateArn,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+
+						"deploy_as_application_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"s3_content_location": {
+										Type:     schema.TypeList,
+										Required: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"bucket_arn": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validateArn,
+												},
+
+												"base_path": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+
+						"custom_artifacts_configuration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"artifact_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											kinesisanalyticsv2.ArtifactTypeDependencyJar,
+											kinesisanalyticsv2.ArtifactTypeUdf,
+										}, false),
+									},
+
+									"s3_content_location": {
+										Type:          schema.TypeList,
+										Optional:      true,
+										MaxItems:      1,
+										ConflictsWith: []string{"zeppelin_application_configuration.0.custom_artifacts_configuration.0.maven_reference"},
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"bucket_arn": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validateArn,
+												},
+
+												"file_key": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+
+												"object_version": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+											},
+										},
+									},
+
+									"maven_reference": {
+										Type:          schema.TypeList,
+										Optional:      true,
+										MaxItems:      1,
+										ConflictsWith: []string{"zeppelin_application_configuration.0.custom_artifacts_configuration.0.s3_content_location"},
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"group_id": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+
+												"artifact_id": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+
+												"version": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsKinesisAnalyticsV2ApplicationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kinesisanalyticsv2conn
+	name := d.Get("name").(string)
+	serviceExecutionRole := d.Get("service_execution_role").(string)
+	runtime := d.Get("runtime").(string)
+	s3Bucket := d.Get("s3_bucket").(string)
+	s3Object := d.Get("s3_object").(string)
+	textCode := d.Get("code").(string)
+	codeContentType := d.Get("code_content_type").(string)
+
+	var sqlApplicationConfiguration *kinesisanalyticsv2.SqlApplicationConfiguration
+	var flinkApplicationConfiguration *kinesisanalyticsv2.FlinkApplicationConfiguration
+	var zeppelinApplicationConfiguration *kinesisanalyticsv2.ZeppelinApplicationConfiguration
+	var customArtifactsConfiguration []*kinesisanalyticsv2.CustomArtifactConfiguration
+	switch {
+	case strings.HasPrefix(runtime, "SQL"):
+		sqlConfig, ok := d.GetOk("sql_application_configuration")
+		if !ok {
+			break
+		}
+		sc := sqlConfig.([]interface{})[0]
+		if sc != nil {
+			sqlApplicationConfiguration = expandKinesisAnalayticsSqlApplicationConfiguration(sc.(map[string]interface{}))
+		}
+
+	case runtimeIsZeppelin(runtime):
+		zeppelinConfig, ok := d.GetOk("zeppelin_application_configuration")
+		if !ok {
+			break
+		}
+		zc := zeppelinConfig.([]interface{})[0]
+		if zc != nil {
+			zcL := zc.(map[string]interface{})
+			zeppelinApplicationConfiguration = expandKinesisAnalyticsV2ZeppelinApplicationConfiguration(zcL)
+			customArtifactsConfiguration = expandKinesisAnalyticsV2CustomArtifacts(zcL["custom_artifacts_configuration"].([]interface{}))
+		}
+
+	case strings.HasPrefix(runtime, "FLINK"):
+		flinkConfig, ok := d.GetOk("flink_application_configuration")
+		if !ok {
+			break
+		}
+		fc := flinkConfig.([]interface{})[0]
+		if fc != nil {
+			flinkApplicationConfiguration = expandKinesisAnalyticsFlinkApplicationConfiguration(fc.(map[string]interface{}))
+			customArtifactsConfiguration = expandKinesisAnalyticsV2CustomArtifactsConfiguration(fc.(map[string]interface{}))
+		}
+	}
+
+	var contentType *string
+	switch codeContentType {
+	case "zip":
+		contentType = aws.String(kinesisanalyticsv2.CodeContentTypeZipfile)
+	case "plain_text":
+		contentType = aws.String(kinesisanalyticsv2.CodeContentTypePlaintext)
+	}
 
 	var s3ContentLocation *kinesisanalyticsv2.S3ContentLocation
 	if s3Bucket != "" && s3Object != "" {
@@ -742,6 +1183,15 @@ func resourceAwsKinesisAnalyticsV2ApplicationCreate(d *schema.ResourceData, meta
 		}
 	}
 
+	var vpcConfigurations []*kinesisanalyticsv2.VpcConfiguration
+	if v, ok := d.GetOk("vpc_configuration"); ok && len(v.([]interface{})) > 0 {
+		if !runtimeIsFlink(d.Get("runtime").(string)) {
+			return fmt.Errorf("vpc_configuration is only supported for Flink (runtime_environment FLINK-*) applications")
+		}
+		vc := v.([]interface{})[0].(map[string]interface{})
+		vpcConfigurations = []*kinesisanalyticsv2.VpcConfiguration{expandKinesisAnalyticsV2VpcConfiguration(vc)}
+	}
+
 	var textContent *string
 	if textCode != "" {
 		textContent = aws.String(textCode)
@@ -754,14 +1204,17 @@ func resourceAwsKinesisAnalyticsV2ApplicationCreate(d *schema.ResourceData, meta
 		ApplicationConfiguration: &kinesisanalyticsv2.ApplicationConfiguration{
 			SqlApplicationConfiguration:      sqlApplicationConfiguration,
 			FlinkApplicationConfiguration:    flinkApplicationConfiguration,
+			ZeppelinApplicationConfiguration: zeppelinApplicationConfiguration,
 			EnvironmentProperties:            environmentProperties,
 			ApplicationSnapshotConfiguration: snapshotConfig,
+			VpcConfigurations:                vpcConfigurations,
 			ApplicationCodeConfiguration: &kinesisanalyticsv2.ApplicationCodeConfiguration{
 				CodeContent: &kinesisanalyticsv2.CodeContent{
 					S3ContentLocation: s3ContentLocation,
 					TextContent:       textContent,
 				},
-				CodeContentType: contentType,
+				CodeContentType:              contentType,
+				CustomArtifactsConfiguration: customArtifactsConfiguration,
 			},
 		},
 	}
@@ -771,9 +1224,12 @@ func resourceAwsKinesisAnalyticsV2ApplicationCreate(d *schema.ResourceData, meta
 	}
 
 	if v, ok := d.GetOk("cloudwatch_logging_options"); ok {
-		clo := v.([]interface{})[0].(map[string]interface{})
-		cloudwatchLoggingOption := expandKinesisAnalyticsV2CloudwatchLoggingOption(clo)
-		createOpts.CloudWatchLoggingOptions = []*kinesisanalyticsv2.CloudWatchLoggingOption{cloudwatchLoggingOption}
+		for _, elem := range v.([]interface{}) {
+			clo := elem.(map[string]interface{})
+			if clo["enabled"].(bool) {
+				createOpts.CloudWatchLoggingOptions = append(createOpts.CloudWatchLoggingOptions, expandKinesisAnalyticsV2CloudwatchLoggingOption(meta, clo))
+			}
+		}
 	}
 
 	if v := d.Get("tags").(map[string]interface{}); len(v) > 0 {
@@ -878,16 +1334,40 @@ func resourceAwsKinesisAnalyticsV2ApplicationRead(d *schema.ResourceData, meta i
 		}
 	}
 	if runtimeIsFlink(runtime) {
-		if err := d.Set("flink_application_configuration", flattenFlinkApplicationConfigurationDescription(resp.ApplicationDetail.ApplicationConfigurationDescription.FlinkApplicationConfigurationDescription)); err != nil {
+		if err := d.Set("flink_application_configuration", flattenFlinkApplicationConfigurationDescription(resp.ApplicationDetail.ApplicationConfigurationDescription.FlinkApplicationConfigurationDescription, resp.ApplicationDetail.ApplicationConfigurationDescription.ApplicationCodeConfigurationDescription)); err != nil {
 			return fmt.Errorf("error setting flink_application_configuration: %s", err)
 		}
 	}
+	if runtimeIsZeppelin(runtime) {
+		if err := d.Set("zeppelin_application_configuration", flattenZeppelinApplicationConfigurationDescription(resp.ApplicationDetail.ApplicationConfigurationDescription.ZeppelinApplicationConfigurationDescription, resp.ApplicationDetail.ApplicationConfigurationDescription.ApplicationCodeConfigurationDescription)); err != nil {
+			return fmt.Errorf("error setting zeppelin_application_configuration: %s", err)
+		}
+	}
 	if resp.ApplicationDetail.ApplicationConfigurationDescription.ApplicationSnapshotConfigurationDescription != nil {
 		if err := d.Set("snapshots_enabled", aws.BoolValue(resp.ApplicationDetail.ApplicationConfigurationDescription.ApplicationSnapshotConfigurationDescription.SnapshotsEnabled)); err != nil {
 			return fmt.Errorf("error setting snapshots_enabled: %s", err)
 		}
 	}
 
+	if err := d.Set("vpc_configuration", flattenKinesisAnalyticsV2VpcConfigurations(resp.ApplicationDetail.ApplicationConfigurationDescription.VpcConfigurationDescriptions)); err != nil {
+		return fmt.Errorf("error setting vpc_configuration: %s", err)
+	}
+
+	if err := d.Set("maintenance_configuration", flattenKinesisAnalyticsV2MaintenanceConfiguration(resp.ApplicationDetail.ApplicationMaintenanceConfigurationDescription)); err != nil {
+		return fmt.Errorf("error setting maintenance_configuration: %s", err)
+	}
+
+	// version_description is the ApplicationDescription of the record that
+	// DescribeApplicationVersion returns for the current ApplicationVersionId.
+	versionResp, err := conn.DescribeApplicationVersion(&kinesisanalyticsv2.DescribeApplicationVersionInput{
+		ApplicationName:      aws.String(name),
+		ApplicationVersionId: resp.ApplicationDetail.ApplicationVersionId,
+	})
+	if err != nil {
+		return fmt.Errorf("error reading Kinesis Analytics Application (%s) version %d: %s", name, aws.Int64Value(resp.ApplicationDetail.ApplicationVersionId), err)
+	}
+	d.Set("version_description", aws.StringValue(versionResp.ApplicationVersionDetail.ApplicationDescription))
+
 	tags, err := keyvaluetags.Kinesisanalyticsv2ListTags(conn, arn)
 
 	if err != nil {
@@ -918,7 +1398,7 @@ func resourceAwsKinesisAnalyticsV2ApplicationUpdate(d *schema.ResourceData, meta
 			CurrentApplicationVersionId: aws.Int64(int64(version)),
 		}
 
-		applicationUpdate, err := createApplicationV2UpdateOpts(d)
+		applicationUpdate, err := createApplicationV2UpdateOpts(d, meta)
 		if err != nil {
 			return err
 		}
@@ -926,18 +1406,50 @@ func resourceAwsKinesisAnalyticsV2ApplicationUpdate(d *schema.ResourceData, meta
 		if !reflect.DeepEqual(applicationUpdate, &kinesisanalyticsv2.UpdateApplicationInput{}) {
 			updateApplicationOpts.SetApplicationConfigurationUpdate(applicationUpdate.ApplicationConfigurationUpdate)
 			updateApplicationOpts.SetCloudWatchLoggingOptionUpdates(applicationUpdate.CloudWatchLoggingOptionUpdates)
-			_, updateErr := conn.UpdateApplication(updateApplicationOpts)
-			if updateErr != nil {
-				return updateErr
+			newVersion, err := updateKinesisAnalyticsV2ApplicationPreservingState(d, conn, name, updateApplicationOpts)
+			if err != nil {
+				return err
 			}
-			version = version + 1
+			// Stop (with a snapshot) and Update can each bump the application
+			// version independently, so trust the live version the helper
+			// observed instead of assuming a single +1, the same way the
+			// vpc_configuration block below re-describes rather than trusting
+			// a running counter.
+			version = newVersion
 		}
 
 		oldLoggingOptions, newLoggingOptions := d.GetChange("cloudwatch_logging_options")
-		if len(oldLoggingOptions.([]interface{})) == 0 && len(newLoggingOptions.([]interface{})) > 0 {
-			if v, ok := d.GetOk("cloudwatch_logging_options"); ok {
-				clo := v.([]interface{})[0].(map[string]interface{})
-				cloudwatchLoggingOption := expandKinesisAnalyticsV2CloudwatchLoggingOption(clo)
+		oldLoggingList := oldLoggingOptions.([]interface{})
+		newLoggingList := newLoggingOptions.([]interface{})
+
+		// Reconcile element-by-element (matched positionally, since
+		// CloudWatchLoggingOptionId isn't known until after Add): any
+		// position that newly becomes enabled is Added, any that newly
+		// becomes disabled (or drops off the list) is Deleted. Positions
+		// that stay enabled on both sides are reconciled in place via
+		// CloudWatchLoggingOptionUpdates in createApplicationV2UpdateOpts.
+		maxLoggingOptions := len(oldLoggingList)
+		if len(newLoggingList) > maxLoggingOptions {
+			maxLoggingOptions = len(newLoggingList)
+		}
+
+		for i := 0; i < maxLoggingOptions; i++ {
+			oldEnabled := false
+			var oldCloudwatchLoggingOptionId string
+			if i < len(oldLoggingList) {
+				oldClo := oldLoggingList[i].(map[string]interface{})
+				oldEnabled = oldClo["enabled"].(bool)
+				oldCloudwatchLoggingOptionId = oldClo["id"].(string)
+			}
+
+			newEnabled := false
+			if i < len(newLoggingList) {
+				newEnabled = newLoggingList[i].(map[string]interface{})["enabled"].(bool)
+			}
+
+			if !oldEnabled && newEnabled {
+				clo := newLoggingList[i].(map[string]interface{})
+				cloudwatchLoggingOption := expandKinesisAnalyticsV2CloudwatchLoggingOption(meta, clo)
 				addOpts := &kinesisanalyticsv2.AddApplicationCloudWatchLoggingOptionInput{
 					ApplicationName:             aws.String(name),
 					CurrentApplicationVersionId: aws.Int64(int64(version)),
@@ -962,90 +1474,590 @@ func resourceAwsKinesisAnalyticsV2ApplicationUpdate(d *schema.ResourceData, meta
 					return fmt.Errorf("Unable to add CloudWatch logging options: %s", err)
 				}
 				version = version + 1
+			} else if oldEnabled && !newEnabled {
+				_, err := conn.DeleteApplicationCloudWatchLoggingOption(&kinesisanalyticsv2.DeleteApplicationCloudWatchLoggingOptionInput{
+					ApplicationName:             aws.String(name),
+					CurrentApplicationVersionId: aws.Int64(int64(version)),
+					CloudWatchLoggingOptionId:   aws.String(oldCloudwatchLoggingOptionId),
+				})
+				if err != nil {
+					return fmt.Errorf("Unable to delete CloudWatch logging options: %s", err)
+				}
+				version = version + 1
 			}
 		}
-		if d.HasChange("sql_application_configuration") {
-			oldConf, newConf := d.GetChange("sql_application_configuration")
-			o := oldConf.([]interface{})[0].(map[string]interface{})
-			n := newConf.([]interface{})[0].(map[string]interface{})
-			oldInputs := o["inputs"].([]interface{})
-			oldOutputs := o["outputs"].([]interface{})
-			newInputs := n["inputs"].([]interface{})
-			newOutputs := n["outputs"].([]interface{})
+		if d.HasChange("vpc_configuration") {
+			oldVpc, newVpc := d.GetChange("vpc_configuration")
+			oldList := oldVpc.([]interface{})
+			newList := newVpc.([]interface{})
+
+			if len(newList) > 0 && !runtimeIsFlink(d.Get("runtime").(string)) {
+				return fmt.Errorf("vpc_configuration is only supported for Flink (runtime_environment FLINK-*) applications")
+			}
+
+			// VPC changes bump the application version out from under us, so
+			// re-read the current version before each mutation instead of
+			// trusting the version tracked above.
+			describeResp, err := conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+				ApplicationName: aws.String(name),
+			})
+			if err != nil {
+				return fmt.Errorf("error reading Kinesis Analytics Application (%s) before VPC configuration update: %s", name, err)
+			}
+			version = int(aws.Int64Value(describeResp.ApplicationDetail.ApplicationVersionId))
+
+			switch {
+			case len(oldList) == 0 && len(newList) > 0:
+				vc := newList[0].(map[string]interface{})
+				addOpts := &kinesisanalyticsv2.AddApplicationVpcConfigurationInput{
+					ApplicationName:             aws.String(name),
+					CurrentApplicationVersionId: aws.Int64(int64(version)),
+					VpcConfiguration:            expandKinesisAnalyticsV2VpcConfiguration(vc),
+				}
+				// Retry for IAM eventual consistency, same as AddApplicationCloudWatchLoggingOption.
+				err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+					_, err := conn.AddApplicationVpcConfiguration(addOpts)
+					if err != nil {
+						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "Kinesis Analytics service doesn't have sufficient privileges") {
+							return resource.RetryableError(err)
+						}
+						return resource.NonRetryableError(err)
+					}
+					return nil
+				})
+				if isResourceTimeoutError(err) {
+					_, err = conn.AddApplicationVpcConfiguration(addOpts)
+				}
+				if err != nil {
+					return fmt.Errorf("error adding Kinesis Analytics Application VPC configuration: %s", err)
+				}
+				version = version + 1
+
+			case len(oldList) > 0 && len(newList) == 0:
+				vc := oldList[0].(map[string]interface{})
+				deleteOpts := &kinesisanalyticsv2.DeleteApplicationVpcConfigurationInput{
+					ApplicationName:             aws.String(name),
+					CurrentApplicationVersionId: aws.Int64(int64(version)),
+					VpcConfigurationId:          aws.String(vc["vpc_configuration_id"].(string)),
+				}
+				if _, err := conn.DeleteApplicationVpcConfiguration(deleteOpts); err != nil {
+					return fmt.Errorf("error deleting Kinesis Analytics Application VPC configuration: %s", err)
+				}
+				version = version + 1
+
+			case len(oldList) > 0 && len(newList) > 0:
+				oldVc := oldList[0].(map[string]interface{})
+				newVc := newList[0].(map[string]interface{})
+				updateOpts := &kinesisanalyticsv2.UpdateApplicationInput{
+					ApplicationName:             aws.String(name),
+					CurrentApplicationVersionId: aws.Int64(int64(version)),
+					ApplicationConfigurationUpdate: &kinesisanalyticsv2.ApplicationConfigurationUpdate{
+						VpcConfigurationUpdates: []*kinesisanalyticsv2.VpcConfigurationUpdate{
+							{
+								VpcConfigurationId:     aws.String(oldVc["vpc_configuration_id"].(string)),
+								SubnetIdUpdates:        expandStringSet(newVc["subnet_ids"].(*schema.Set)),
+								SecurityGroupIdUpdates: expandStringSet(newVc["security_group_ids"].(*schema.Set)),
+							},
+						},
+					},
+				}
+				if _, err := conn.UpdateApplication(updateOpts); err != nil {
+					return fmt.Errorf("error updating Kinesis Analytics Application VPC configuration: %s", err)
+				}
+				version = version + 1
+			}
+		}
+		if d.HasChange("sql_application_configuration") {
+			oldConf, newConf := d.GetChange("sql_application_configuration")
+			oldConfList := oldConf.([]interface{})
+			newConfList := newConf.([]interface{})
+			// sql_application_configuration is itself optional, so toggling
+			// it on or off (not just its sub-fields) is a valid transition
+			// that leaves one side with a zero-length list.
+			o := map[string]interface{}{}
+			if len(oldConfList) > 0 {
+				o = oldConfList[0].(map[string]interface{})
+			}
+			n := map[string]interface{}{}
+			if len(newConfList) > 0 {
+				n = newConfList[0].(map[string]interface{})
+			}
+			oldInputs, _ := o["inputs"].([]interface{})
+			oldOutputs, _ := o["outputs"].([]interface{})
+			oldReferenceDataSources, _ := o["reference_data_sources"].([]interface{})
+			newInputs, _ := n["inputs"].([]interface{})
+			newOutputs, _ := n["outputs"].([]interface{})
+			newReferenceDataSources, _ := n["reference_data_sources"].([]interface{})
+
+			if len(oldInputs) == 0 && len(newInputs) > 0 {
+				i := newInputs[0].(map[string]interface{})
+				input := expandKinesisAnalyticsV2Inputs(i)
+				addOpts := &kinesisanalyticsv2.AddApplicationInputInput{
+					ApplicationName:             aws.String(name),
+					CurrentApplicationVersionId: aws.Int64(int64(version)),
+					Input:                       input,
+				}
+				// Retry for IAM eventual consistency
+				err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+					_, err := conn.AddApplicationInput(addOpts)
+					if err != nil {
+						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "Kinesis Analytics service doesn't have sufficient privileges") {
+							return resource.RetryableError(err)
+						}
+						// InvalidArgumentException: Given IAM role arn : arn:aws:iam::123456789012:role/xxx does not provide Invoke permissions on the Lambda resource : arn:aws:lambda:us-west-2:123456789012:function:yyy
+						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "does not provide Invoke permissions on the Lambda resource") {
+							return resource.RetryableError(err)
+						}
+						return resource.NonRetryableError(err)
+					}
+					return nil
+				})
+				if isResourceTimeoutError(err) {
+					_, err = conn.AddApplicationInput(addOpts)
+				}
+
+				if err != nil {
+					return fmt.Errorf("Unable to add application inputs: %s", err)
+				}
+				version = version + 1
+			}
+			if len(oldOutputs) == 0 && len(newOutputs) > 0 {
+				o := newOutputs[0].(map[string]interface{})
+				output := expandKinesisAnalyticsV2Outputs(o)
+				addOpts := &kinesisanalyticsv2.AddApplicationOutputInput{
+					ApplicationName:             aws.String(name),
+					CurrentApplicationVersionId: aws.Int64(int64(version)),
+					Output:                      output,
+				}
+				// Retry for IAM eventual consistency
+				err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+					_, err := conn.AddApplicationOutput(addOpts)
+					if err != nil {
+						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "Kinesis Analytics service doesn't have sufficient privileges") {
+							return resource.RetryableError(err)
+						}
+						// InvalidArgumentException: Given IAM role arn : arn:aws:iam::123456789012:role/xxx does not provide Invoke permissions on the Lambda resource : arn:aws:lambda:us-west-2:123456789012:function:yyy
+						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "does not provide Invoke permissions on the Lambda resource") {
+							return resource.RetryableError(err)
+						}
+						return resource.NonRetryableError(err)
+					}
+					return nil
+				})
+				if isResourceTimeoutError(err) {
+					_, err = conn.AddApplicationOutput(addOpts)
+				}
+				if err != nil {
+					return fmt.Errorf("Unable to add application outputs: %s", err)
+				}
+				version = version + 1
+			} else if len(oldOutputs) > 0 && len(newOutputs) == 0 {
+				o := oldOutputs[0].(map[string]interface{})
+				_, err := conn.DeleteApplicationOutput(&kinesisanalyticsv2.DeleteApplicationOutputInput{
+					ApplicationName:             aws.String(name),
+					CurrentApplicationVersionId: aws.Int64(int64(version)),
+					OutputId:                    aws.String(o["id"].(string)),
+				})
+				if err != nil {
+					return fmt.Errorf("Unable to delete application outputs: %s", err)
+				}
+				version = version + 1
+			}
+			if len(oldReferenceDataSources) == 0 && len(newReferenceDataSources) > 0 {
+				r := newReferenceDataSources[0].(map[string]interface{})
+				referenceDataSource := expandKinesisAnalyticsV2ReferenceData(r)
+				addOpts := &kinesisanalyticsv2.AddApplicationReferenceDataSourceInput{
+					ApplicationName:             aws.String(name),
+					CurrentApplicationVersionId: aws.Int64(int64(version)),
+					ReferenceDataSource:         referenceDataSource,
+				}
+				// Retry for IAM eventual consistency
+				err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+					_, err := conn.AddApplicationReferenceDataSource(addOpts)
+					if err != nil {
+						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "Kinesis Analytics service doesn't have sufficient privileges") {
+							return resource.RetryableError(err)
+						}
+						return resource.NonRetryableError(err)
+					}
+					return nil
+				})
+				if isResourceTimeoutError(err) {
+					_, err = conn.AddApplicationReferenceDataSource(addOpts)
+				}
+				if err != nil {
+					return fmt.Errorf("Unable to add application reference data source: %s", err)
+				}
+				version = version + 1
+			} else if len(oldReferenceDataSources) > 0 && len(newReferenceDataSources) == 0 {
+				r := oldReferenceDataSources[0].(map[string]interface{})
+				_, err := conn.DeleteApplicationReferenceDataSource(&kinesisanalyticsv2.DeleteApplicationReferenceDataSourceInput{
+					ApplicationName:             aws.String(name),
+					CurrentApplicationVersionId: aws.Int64(int64(version)),
+					ReferenceId:                 aws.String(r["id"].(string)),
+				})
+				if err != nil {
+					return fmt.Errorf("Unable to delete application reference data source: %s", err)
+				}
+				version = version + 1
+			}
+		}
+		arn := d.Get("arn").(string)
+		if d.HasChange("tags") {
+			o, n := d.GetChange("tags")
+			if err := keyvaluetags.Kinesisanalyticsv2UpdateTags(conn, arn, o, n); err != nil {
+				return fmt.Errorf("error updating Kinesis Analytics Application (%s) tags: %s", arn, err)
+			}
+		}
+	}
+
+	// UpdateApplicationMaintenanceConfiguration isn't part of
+	// UpdateApplicationInput and doesn't bump the application version, so it's
+	// applied on its own and runs on create too (CreateApplicationInput has no
+	// equivalent field).
+	if d.HasChange("maintenance_configuration") {
+		if v, ok := d.GetOk("maintenance_configuration"); ok && len(v.([]interface{})) > 0 {
+			mc := v.([]interface{})[0].(map[string]interface{})
+			_, err := conn.UpdateApplicationMaintenanceConfiguration(&kinesisanalyticsv2.UpdateApplicationMaintenanceConfigurationInput{
+				ApplicationName: aws.String(name),
+				ApplicationMaintenanceConfigurationUpdate: &kinesisanalyticsv2.ApplicationMaintenanceConfigurationUpdate{
+					ApplicationMaintenanceWindowStartTimeUpdate: aws.String(mc["application_maintenance_window_start_time"].(string)),
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("error updating Kinesis Analytics Application (%s) maintenance configuration: %s", name, err)
+			}
+		}
+	}
+
+	if err := resourceAwsKinesisAnalyticsV2ApplicationSetRunState(d, meta); err != nil {
+		return err
+	}
+
+	return resourceAwsKinesisAnalyticsV2ApplicationRead(d, meta)
+}
+
+// resourceAwsKinesisAnalyticsV2ApplicationSetRunState starts or stops the
+// application to match start_application, transitioning through
+// StopApplication/StartApplication as needed so an update lands on a
+// running application.
+func resourceAwsKinesisAnalyticsV2ApplicationSetRunState(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kinesisanalyticsv2conn
+	name := d.Get("name").(string)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	descResp, err := conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+		ApplicationName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("error reading Kinesis Analytics Application (%s) status: %s", name, err)
+	}
+	status := aws.StringValue(descResp.ApplicationDetail.ApplicationStatus)
+
+	if d.Get("start_application").(bool) {
+		if status == kinesisanalyticsv2.ApplicationStatusReady {
+			var runConfiguration *kinesisanalyticsv2.RunConfiguration
+			if v, ok := d.GetOk("run_configuration"); ok && len(v.([]interface{})) > 0 {
+				runConfiguration = expandKinesisAnalyticsV2RunConfiguration(v.([]interface{})[0].(map[string]interface{}), descResp.ApplicationDetail)
+			}
+
+			startOpts := &kinesisanalyticsv2.StartApplicationInput{
+				ApplicationName:  aws.String(name),
+				RunConfiguration: runConfiguration,
+			}
+			// Start/Stop conflict with in-flight updates, so retry on ResourceInUseException.
+			err := resource.Retry(timeout, func() *resource.RetryError {
+				_, err := conn.StartApplication(startOpts)
+				if err != nil {
+					if isAWSErr(err, kinesisanalyticsv2.ErrCodeResourceInUseException, "") {
+						return resource.RetryableError(err)
+					}
+					return resource.NonRetryableError(err)
+				}
+				return nil
+			})
+			if isResourceTimeoutError(err) {
+				_, err = conn.StartApplication(startOpts)
+			}
+			if err != nil {
+				return fmt.Errorf("error starting Kinesis Analytics Application (%s): %s", name, err)
+			}
+
+			if err := waitForKinesisAnalyticsV2ApplicationStatus(conn, name, kinesisanalyticsv2.ApplicationStatusRunning, timeout); err != nil {
+				return fmt.Errorf("error waiting for Kinesis Analytics Application (%s) to start: %s", name, err)
+			}
+		}
+	} else {
+		if status == kinesisanalyticsv2.ApplicationStatusRunning {
+			if err := stopKinesisAnalyticsV2Application(conn, name, d.Get("force_stop").(bool), timeout); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func stopKinesisAnalyticsV2Application(conn *kinesisanalyticsv2.KinesisAnalyticsV2, name string, force bool, timeout time.Duration) error {
+	stopOpts := &kinesisanalyticsv2.StopApplicationInput{
+		ApplicationName: aws.String(name),
+		Force:           aws.Bool(force),
+	}
+	// Start/Stop conflict with in-flight updates, so retry on ResourceInUseException.
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		_, err := conn.StopApplication(stopOpts)
+		if err != nil {
+			if isAWSErr(err, kinesisanalyticsv2.ErrCodeResourceInUseException, "") {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		_, err = conn.StopApplication(stopOpts)
+	}
+	if err != nil {
+		return fmt.Errorf("error stopping Kinesis Analytics Application (%s): %s", name, err)
+	}
+
+	if err := waitForKinesisAnalyticsV2ApplicationStatus(conn, name, kinesisanalyticsv2.ApplicationStatusReady, timeout); err != nil {
+		return fmt.Errorf("error waiting for Kinesis Analytics Application (%s) to stop: %s", name, err)
+	}
+
+	return nil
+}
+
+func waitForKinesisAnalyticsV2ApplicationStatus(conn *kinesisanalyticsv2.KinesisAnalyticsV2, name, target string, timeout time.Duration) error {
+	stateConf := resource.StateChangeConf{
+		Pending: []string{
+			kinesisanalyticsv2.ApplicationStatusStarting,
+			kinesisanalyticsv2.ApplicationStatusStopping,
+			kinesisanalyticsv2.ApplicationStatusUpdating,
+		},
+		Target:  []string{target},
+		Timeout: timeout,
+		Refresh: refreshKinesisAnalyticsApplicationStatusV2(conn, name),
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// updateKinesisAnalyticsV2ApplicationWithRollback calls UpdateApplication and,
+// when rollback_on_update_failure is set, rolls the application back to its
+// prior version if the call itself fails outright or the application sits in
+// UPDATING past the update timeout.
+func updateKinesisAnalyticsV2ApplicationWithRollback(d *schema.ResourceData, conn *kinesisanalyticsv2.KinesisAnalyticsV2, name string, input *kinesisanalyticsv2.UpdateApplicationInput) error {
+	rollbackOnFailure := d.Get("rollback_on_update_failure").(bool)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	_, err := conn.UpdateApplication(input)
+	if err != nil {
+		if rollbackOnFailure {
+			if rbErr := rollbackKinesisAnalyticsV2Application(conn, name, timeout); rbErr != nil {
+				return fmt.Errorf("error updating Kinesis Analytics Application (%s): %s (rollback failed: %s)", name, err, rbErr)
+			}
+			return fmt.Errorf("error updating Kinesis Analytics Application (%s), rolled back to previous version: %s", name, err)
+		}
+		return err
+	}
+
+	if rollbackOnFailure {
+		if err := waitForKinesisAnalyticsV2ApplicationStatusNotUpdating(conn, name, timeout); err != nil {
+			if rbErr := rollbackKinesisAnalyticsV2Application(conn, name, timeout); rbErr != nil {
+				return fmt.Errorf("Kinesis Analytics Application (%s) update did not complete: %s (rollback failed: %s)", name, err, rbErr)
+			}
+			return fmt.Errorf("Kinesis Analytics Application (%s) update did not complete within %s, rolled back to previous version: %s", name, timeout, err)
+		}
+	}
+
+	return nil
+}
+
+// updateKinesisAnalyticsV2ApplicationPreservingState wraps
+// updateKinesisAnalyticsV2ApplicationWithRollback so an UpdateApplication
+// against a RUNNING application doesn't just land an in-place diff: the
+// application is stopped first (taking a snapshot if snapshots_enabled),
+// updated, and then, if start_application is set, restarted from
+// RESTORE_FROM_LATEST_SNAPSHOT (SKIP_RESTORE_FROM_SNAPSHOT when
+// snapshots_enabled is false), so a rolling update preserves Flink state
+// instead of requiring a manual stop/start cycle from the caller. It returns the application's live
+// ApplicationVersionId after the sequence completes, since Stop and Update
+// can each bump it independently of the version the caller passed in; the
+// caller must use this to keep any further version-scoped calls (e.g.
+// cloudwatch_logging_options Add/Delete) from using a stale version.
+func updateKinesisAnalyticsV2ApplicationPreservingState(d *schema.ResourceData, conn *kinesisanalyticsv2.KinesisAnalyticsV2, name string, input *kinesisanalyticsv2.UpdateApplicationInput) (int, error) {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	descResp, err := conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+		ApplicationName: aws.String(name),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error reading Kinesis Analytics Application (%s) status: %s", name, err)
+	}
+	wasRunning := aws.StringValue(descResp.ApplicationDetail.ApplicationStatus) == kinesisanalyticsv2.ApplicationStatusRunning
+
+	if wasRunning {
+		if err := stopKinesisAnalyticsV2Application(conn, name, d.Get("force_stop").(bool), timeout); err != nil {
+			return 0, err
+		}
+
+		// Stopping (with a snapshot, if snapshots_enabled) bumps the
+		// application version out from under the version passed in by the
+		// caller, so re-read it before updating.
+		descResp, err = conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+			ApplicationName: aws.String(name),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("error reading Kinesis Analytics Application (%s) status: %s", name, err)
+		}
+		input.CurrentApplicationVersionId = descResp.ApplicationDetail.ApplicationVersionId
+	}
+
+	if err := updateKinesisAnalyticsV2ApplicationWithRollback(d, conn, name, input); err != nil {
+		return 0, err
+	}
+
+	// Only restart if the caller still wants the application running;
+	// otherwise resourceAwsKinesisAnalyticsV2ApplicationSetRunState would
+	// immediately stop it again, needlessly cycling a Flink job the user
+	// asked to keep stopped through a brief live restart.
+	if !wasRunning || !d.Get("start_application").(bool) {
+		descResp, err = conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+			ApplicationName: aws.String(name),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("error reading Kinesis Analytics Application (%s) status: %s", name, err)
+		}
+		return int(aws.Int64Value(descResp.ApplicationDetail.ApplicationVersionId)), nil
+	}
+
+	restoreType := kinesisanalyticsv2.ApplicationRestoreTypeSkipRestoreFromSnapshot
+	if d.Get("snapshots_enabled").(bool) {
+		restoreType = kinesisanalyticsv2.ApplicationRestoreTypeRestoreFromLatestSnapshot
+	}
+	startOpts := &kinesisanalyticsv2.StartApplicationInput{
+		ApplicationName: aws.String(name),
+		RunConfiguration: &kinesisanalyticsv2.RunConfiguration{
+			ApplicationRestoreConfiguration: &kinesisanalyticsv2.ApplicationRestoreConfiguration{
+				ApplicationRestoreType: aws.String(restoreType),
+			},
+		},
+	}
+	// Start/Stop conflict with in-flight updates, same as resourceAwsKinesisAnalyticsV2ApplicationSetRunState.
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		_, err := conn.StartApplication(startOpts)
+		if err != nil {
+			if isAWSErr(err, kinesisanalyticsv2.ErrCodeResourceInUseException, "") {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		_, err = conn.StartApplication(startOpts)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error restarting Kinesis Analytics Application (%s) after update: %s", name, err)
+	}
+
+	if err := waitForKinesisAnalyticsV2ApplicationStatus(conn, name, kinesisanalyticsv2.ApplicationStatusRunning, timeout); err != nil {
+		return 0, err
+	}
+
+	descResp, err = conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+		ApplicationName: aws.String(name),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error reading Kinesis Analytics Application (%s) status: %s", name, err)
+	}
+	return int(aws.Int64Value(descResp.ApplicationDetail.ApplicationVersionId)), nil
+}
+
+// rollbackKinesisAnalyticsV2Application invokes RollbackApplication to revert
+// to the application's prior version and waits for the application to settle.
+func rollbackKinesisAnalyticsV2Application(conn *kinesisanalyticsv2.KinesisAnalyticsV2, name string, timeout time.Duration) error {
+	descResp, err := conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+		ApplicationName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("error reading Kinesis Analytics Application (%s) before rollback: %s", name, err)
+	}
+
+	_, err = conn.RollbackApplication(&kinesisanalyticsv2.RollbackApplicationInput{
+		ApplicationName:             aws.String(name),
+		CurrentApplicationVersionId: descResp.ApplicationDetail.ApplicationVersionId,
+	})
+	if err != nil {
+		return fmt.Errorf("error rolling back Kinesis Analytics Application (%s): %s", name, err)
+	}
+
+	return waitForKinesisAnalyticsV2ApplicationStatusNotUpdating(conn, name, timeout)
+}
+
+// waitForKinesisAnalyticsV2ApplicationStatusNotUpdating waits for an
+// in-flight UpdateApplication/RollbackApplication to move the application out
+// of UPDATING, regardless of which status it lands in (RUNNING vs READY
+// depends on whether the application was running before the update).
+func waitForKinesisAnalyticsV2ApplicationStatusNotUpdating(conn *kinesisanalyticsv2.KinesisAnalyticsV2, name string, timeout time.Duration) error {
+	stateConf := resource.StateChangeConf{
+		Pending: []string{kinesisanalyticsv2.ApplicationStatusUpdating},
+		Target: []string{
+			kinesisanalyticsv2.ApplicationStatusReady,
+			kinesisanalyticsv2.ApplicationStatusRunning,
+		},
+		Timeout: timeout,
+		Refresh: refreshKinesisAnalyticsApplicationStatusV2(conn, name),
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func expandKinesisAnalyticsV2RunConfiguration(rc map[string]interface{}, appDetail *kinesisanalyticsv2.ApplicationDetail) *kinesisanalyticsv2.RunConfiguration {
+	runConfiguration := &kinesisanalyticsv2.RunConfiguration{}
+
+	if v := rc["application_restore_configuration"].([]interface{}); len(v) > 0 {
+		arc := v[0].(map[string]interface{})
+		restoreConfig := &kinesisanalyticsv2.ApplicationRestoreConfiguration{
+			ApplicationRestoreType: aws.String(arc["application_restore_type"].(string)),
+		}
+		if sn, ok := arc["snapshot_name"]; ok && sn.(string) != "" {
+			restoreConfig.SnapshotName = aws.String(sn.(string))
+		}
+		runConfiguration.ApplicationRestoreConfiguration = restoreConfig
+	}
+
+	if v := rc["flink_run_configuration"].([]interface{}); len(v) > 0 {
+		frc := v[0].(map[string]interface{})
+		runConfiguration.FlinkRunConfiguration = &kinesisanalyticsv2.FlinkRunConfiguration{
+			AllowNonRestoredState: aws.Bool(frc["allow_non_restored_state"].(bool)),
+		}
+	}
 
-			if len(oldInputs) == 0 && len(newInputs) > 0 {
-				i := newInputs[0].(map[string]interface{})
-				input := expandKinesisAnalyticsV2Inputs(i)
-				addOpts := &kinesisanalyticsv2.AddApplicationInputInput{
-					ApplicationName:             aws.String(name),
-					CurrentApplicationVersionId: aws.Int64(int64(version)),
-					Input:                       input,
-				}
-				// Retry for IAM eventual consistency
-				err := resource.Retry(1*time.Minute, func() *resource.RetryError {
-					_, err := conn.AddApplicationInput(addOpts)
-					if err != nil {
-						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "Kinesis Analytics service doesn't have sufficient privileges") {
-							return resource.RetryableError(err)
-						}
-						// InvalidArgumentException: Given IAM role arn : arn:aws:iam::123456789012:role/xxx does not provide Invoke permissions on the Lambda resource : arn:aws:lambda:us-west-2:123456789012:function:yyy
-						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "does not provide Invoke permissions on the Lambda resource") {
-							return resource.RetryableError(err)
-						}
-						return resource.NonRetryableError(err)
-					}
-					return nil
-				})
-				if isResourceTimeoutError(err) {
-					_, err = conn.AddApplicationInput(addOpts)
-				}
+	// SQL applications don't take inputs directly on StartApplicationInput;
+	// instead every existing input is started from the requested position, so
+	// resolve the current InputIds from the application's own description.
+	if v := rc["sql_run_configuration"].([]interface{}); len(v) > 0 {
+		src := v[0].(map[string]interface{})
+		spc := src["input_starting_position_configuration"].([]interface{})[0].(map[string]interface{})
+		startingPositionConfiguration := &kinesisanalyticsv2.InputStartingPositionConfiguration{
+			InputStartingPosition: aws.String(spc["starting_position"].(string)),
+		}
 
-				if err != nil {
-					return fmt.Errorf("Unable to add application inputs: %s", err)
-				}
-				version = version + 1
-			}
-			if len(oldOutputs) == 0 && len(newOutputs) > 0 {
-				o := newOutputs[0].(map[string]interface{})
-				output := expandKinesisAnalyticsV2Outputs(o)
-				addOpts := &kinesisanalyticsv2.AddApplicationOutputInput{
-					ApplicationName:             aws.String(name),
-					CurrentApplicationVersionId: aws.Int64(int64(version)),
-					Output:                      output,
-				}
-				// Retry for IAM eventual consistency
-				err := resource.Retry(1*time.Minute, func() *resource.RetryError {
-					_, err := conn.AddApplicationOutput(addOpts)
-					if err != nil {
-						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "Kinesis Analytics service doesn't have sufficient privileges") {
-							return resource.RetryableError(err)
-						}
-						// InvalidArgumentException: Given IAM role arn : arn:aws:iam::123456789012:role/xxx does not provide Invoke permissions on the Lambda resource : arn:aws:lambda:us-west-2:123456789012:function:yyy
-						if isAWSErr(err, kinesisanalyticsv2.ErrCodeInvalidArgumentException, "does not provide Invoke permissions on the Lambda resource") {
-							return resource.RetryableError(err)
-						}
-						return resource.NonRetryableError(err)
-					}
-					return nil
+		var sqlRunConfigurations []*kinesisanalyticsv2.SqlRunConfiguration
+		if appDetail != nil && appDetail.ApplicationConfigurationDescription != nil && appDetail.ApplicationConfigurationDescription.SqlApplicationConfigurationDescription != nil {
+			for _, id := range appDetail.ApplicationConfigurationDescription.SqlApplicationConfigurationDescription.InputDescriptions {
+				sqlRunConfigurations = append(sqlRunConfigurations, &kinesisanalyticsv2.SqlRunConfiguration{
+					InputId:                            id.InputId,
+					InputStartingPositionConfiguration: startingPositionConfiguration,
 				})
-				if isResourceTimeoutError(err) {
-					_, err = conn.AddApplicationOutput(addOpts)
-				}
-				if err != nil {
-					return fmt.Errorf("Unable to add application outputs: %s", err)
-				}
-			}
-		}
-		arn := d.Get("arn").(string)
-		if d.HasChange("tags") {
-			o, n := d.GetChange("tags")
-			if err := keyvaluetags.Kinesisanalyticsv2UpdateTags(conn, arn, o, n); err != nil {
-				return fmt.Errorf("error updating Kinesis Analytics Application (%s) tags: %s", arn, err)
 			}
 		}
+		runConfiguration.SqlRunConfigurations = sqlRunConfigurations
 	}
 
-	return resourceAwsKinesisAnalyticsV2ApplicationRead(d, meta)
+	return runConfiguration
 }
 
 func resourceAwsKinesisAnalyticsV2ApplicationDelete(d *schema.ResourceData, meta interface{}) error {
@@ -1056,6 +2068,12 @@ func resourceAwsKinesisAnalyticsV2ApplicationDelete(d *schema.ResourceData, meta
 		return parseErr
 	}
 
+	if d.Get("status").(string) == kinesisanalyticsv2.ApplicationStatusRunning {
+		if err := stopKinesisAnalyticsV2Application(conn, name, d.Get("force_stop").(bool), d.Timeout(schema.TimeoutDelete)); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("[DEBUG] Kinesis Analytics Application destroy: %v", d.Id())
 	deleteOpts := &kinesisanalyticsv2.DeleteApplicationInput{
 		ApplicationName: aws.String(name),
@@ -1074,13 +2092,31 @@ func resourceAwsKinesisAnalyticsV2ApplicationDelete(d *schema.ResourceData, meta
 	return nil
 }
 
-func expandKinesisAnalyticsV2CloudwatchLoggingOption(clo map[string]interface{}) *kinesisanalyticsv2.CloudWatchLoggingOption {
+func expandKinesisAnalyticsV2CloudwatchLoggingOption(meta interface{}, clo map[string]interface{}) *kinesisanalyticsv2.CloudWatchLoggingOption {
 	cloudwatchLoggingOption := &kinesisanalyticsv2.CloudWatchLoggingOption{
-		LogStreamARN: aws.String(clo["log_stream_arn"].(string)),
+		LogStreamARN: aws.String(resolveKinesisAnalyticsV2LogStreamArn(meta, clo)),
 	}
 	return cloudwatchLoggingOption
 }
 
+// resolveKinesisAnalyticsV2LogStreamArn returns log_stream_arn if set, or
+// builds the equivalent ARN from the log_group_name/log_stream_name
+// convenience fields, mirroring aws_kinesis_firehose_delivery_stream.
+func resolveKinesisAnalyticsV2LogStreamArn(meta interface{}, clo map[string]interface{}) string {
+	if v, ok := clo["log_stream_arn"]; ok && v.(string) != "" {
+		return v.(string)
+	}
+
+	client := meta.(*AWSClient)
+	return arn.ARN{
+		Partition: client.partition,
+		Service:   "logs",
+		Region:    client.region,
+		AccountID: client.accountid,
+		Resource:  fmt.Sprintf("log-group:%s:log-stream:%s", clo["log_group_name"].(string), clo["log_stream_name"].(string)),
+	}.String()
+}
+
 func expandPropertyGroups(i []interface{}) []*kinesisanalyticsv2.PropertyGroup {
 	propertyGroups := []*kinesisanalyticsv2.PropertyGroup{}
 	for _, v := range i {
@@ -1256,6 +2292,41 @@ func expandKinesisAnalyticsV2SourceSchema(vL map[string]interface{}) *kinesisana
 	return ss
 }
 
+func expandKinesisAnalyticsV2VpcConfiguration(vc map[string]interface{}) *kinesisanalyticsv2.VpcConfiguration {
+	return &kinesisanalyticsv2.VpcConfiguration{
+		SubnetIds:        expandStringSet(vc["subnet_ids"].(*schema.Set)),
+		SecurityGroupIds: expandStringSet(vc["security_group_ids"].(*schema.Set)),
+	}
+}
+
+func flattenKinesisAnalyticsV2VpcConfigurations(configs []*kinesisanalyticsv2.VpcConfigurationDescription) []interface{} {
+	if len(configs) == 0 {
+		return []interface{}{}
+	}
+
+	vc := configs[0]
+	return []interface{}{
+		map[string]interface{}{
+			"vpc_configuration_id": aws.StringValue(vc.VpcConfigurationId),
+			"vpc_id":               aws.StringValue(vc.VpcId),
+			"subnet_ids":           flattenStringSet(vc.SubnetIds),
+			"security_group_ids":   flattenStringSet(vc.SecurityGroupIds),
+		},
+	}
+}
+
+func flattenKinesisAnalyticsV2MaintenanceConfiguration(mc *kinesisanalyticsv2.ApplicationMaintenanceConfigurationDescription) []interface{} {
+	if mc == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"application_maintenance_window_start_time": aws.StringValue(mc.ApplicationMaintenanceWindowStartTime),
+		},
+	}
+}
+
 func expandKinesisAnalyticsV2ReferenceData(rd map[string]interface{}) *kinesisanalyticsv2.ReferenceDataSource {
 	referenceData := &kinesisanalyticsv2.ReferenceDataSource{
 		TableName: aws.String(rd["table_name"].(string)),
@@ -1278,7 +2349,7 @@ func expandKinesisAnalyticsV2ReferenceData(rd map[string]interface{}) *kinesisan
 	return referenceData
 }
 
-func createApplicationV2UpdateOpts(d *schema.ResourceData) (*kinesisanalyticsv2.UpdateApplicationInput, error) {
+func createApplicationV2UpdateOpts(d *schema.ResourceData, meta interface{}) (*kinesisanalyticsv2.UpdateApplicationInput, error) {
 	applicationUpdate := &kinesisanalyticsv2.UpdateApplicationInput{}
 
 	if d.HasChange("code") {
@@ -1294,13 +2365,24 @@ func createApplicationV2UpdateOpts(d *schema.ResourceData) (*kinesisanalyticsv2.
 	}
 
 	oldLoggingOptions, newLoggingOptions := d.GetChange("cloudwatch_logging_options")
-	if len(oldLoggingOptions.([]interface{})) > 0 && len(newLoggingOptions.([]interface{})) > 0 {
-		if v, ok := d.GetOk("cloudwatch_logging_options"); ok {
-			clo := v.([]interface{})[0].(map[string]interface{})
-			cloudwatchLoggingOption := expandKinesisAnalyticsV2CloudwatchLoggingOptionUpdate(clo)
-			applicationUpdate.CloudWatchLoggingOptionUpdates = []*kinesisanalyticsv2.CloudWatchLoggingOptionUpdate{cloudwatchLoggingOption}
+	oldLoggingList := oldLoggingOptions.([]interface{})
+	newLoggingList := newLoggingOptions.([]interface{})
+	// Positions enabled before and after are reconciled in place via
+	// CloudWatchLoggingOptionUpdates. The enabled=>disabled and
+	// disabled=>enabled transitions are handled separately via
+	// DeleteApplicationCloudWatchLoggingOption/AddApplicationCloudWatchLoggingOption
+	// in the Update function so CloudWatchLoggingOptionId stays accurate.
+	var cloudwatchLoggingOptionUpdates []*kinesisanalyticsv2.CloudWatchLoggingOptionUpdate
+	for i := 0; i < len(oldLoggingList) && i < len(newLoggingList); i++ {
+		oldClo := oldLoggingList[i].(map[string]interface{})
+		newClo := newLoggingList[i].(map[string]interface{})
+		if oldClo["enabled"].(bool) && newClo["enabled"].(bool) {
+			cloudwatchLoggingOptionUpdates = append(cloudwatchLoggingOptionUpdates, expandKinesisAnalyticsV2CloudwatchLoggingOptionUpdate(meta, newClo))
 		}
 	}
+	if len(cloudwatchLoggingOptionUpdates) > 0 {
+		applicationUpdate.CloudWatchLoggingOptionUpdates = cloudwatchLoggingOptionUpdates
+	}
 
 	runtime := d.Get("runtime").(string)
 	var sqlUpdate *kinesisanalyticsv2.SqlApplicationConfigurationUpdate
@@ -1327,13 +2409,64 @@ func createApplicationV2UpdateOpts(d *schema.ResourceData) (*kinesisanalyticsv2.
 		}
 	}
 
-	if sqlUpdate != nil || flinkUpdate != nil || propertyGroupsUpdate != nil || snapshotUpdate != nil {
+	var codeConfigUpdate *kinesisanalyticsv2.ApplicationCodeConfigurationUpdate
+	if runtimeIsFlink(runtime) && d.HasChange("flink_application_configuration") {
+		fc := d.Get("flink_application_configuration").([]interface{})[0].(map[string]interface{})
+		if customArtifacts := expandKinesisAnalyticsV2CustomArtifactsConfiguration(fc); len(customArtifacts) > 0 {
+			codeConfigUpdate = &kinesisanalyticsv2.ApplicationCodeConfigurationUpdate{
+				CustomArtifactsConfigurationUpdate: customArtifacts,
+			}
+		}
+	}
+
+	// Scoped to the custom_artifacts_configuration sub-path (rather than the
+	// whole zeppelin_application_configuration block, as Flink's diff above
+	// does) so adding/removing a monitoring_configuration or
+	// catalog_configuration change doesn't unnecessarily re-diff artifacts.
+	if zc := d.Get("zeppelin_application_configuration").([]interface{}); runtimeIsZeppelin(runtime) && len(zc) > 0 && d.HasChange("zeppelin_application_configuration.0.custom_artifacts_configuration") {
+		zcL := zc[0].(map[string]interface{})
+		if customArtifacts := expandKinesisAnalyticsV2CustomArtifacts(zcL["custom_artifacts_configuration"].([]interface{})); len(customArtifacts) > 0 {
+			codeConfigUpdate = &kinesisanalyticsv2.ApplicationCodeConfigurationUpdate{
+				CustomArtifactsConfigurationUpdate: customArtifacts,
+			}
+		}
+	}
+
+	var zeppelinUpdate *kinesisanalyticsv2.ZeppelinApplicationConfigurationUpdate
+	if runtimeIsZeppelin(runtime) {
+		zeppelinUpdate = createKinesisAnalyticsV2ZeppelinUpdateOpts(d)
+	}
+
+	// source_code_hash doesn't map to any API field itself; it just forces
+	// this diff so S3-backed code changes that don't touch
+	// s3_bucket/s3_object/object_version (e.g. a JAR rebuilt in place) still
+	// trigger a redeploy.
+	if d.HasChange("source_code_hash") {
+		if codeConfigUpdate == nil {
+			codeConfigUpdate = &kinesisanalyticsv2.ApplicationCodeConfigurationUpdate{}
+		}
+		s3ContentLocationUpdate := &kinesisanalyticsv2.S3ContentLocationUpdate{
+			BucketARNUpdate: aws.String(d.Get("s3_bucket").(string)),
+			FileKeyUpdate:   aws.String(d.Get("s3_object").(string)),
+		}
+		if version, ok := d.GetOk("object_version"); ok {
+			s3ContentLocationUpdate.ObjectVersionUpdate = aws.String(version.(string))
+		}
+		codeConfigUpdate.CodeContentUpdate = &kinesisanalyticsv2.CodeContentUpdate{
+			S3ContentLocationUpdate: s3ContentLocationUpdate,
+		}
+	}
+
+	if sqlUpdate != nil || flinkUpdate != nil || zeppelinUpdate != nil || propertyGroupsUpdate != nil || snapshotUpdate != nil || codeConfigUpdate != nil {
 		applicationUpdate = &kinesisanalyticsv2.UpdateApplicationInput{
+			CloudWatchLoggingOptionUpdates: applicationUpdate.CloudWatchLoggingOptionUpdates,
 			ApplicationConfigurationUpdate: &kinesisanalyticsv2.ApplicationConfigurationUpdate{
 				SqlApplicationConfigurationUpdate:      sqlUpdate,
 				FlinkApplicationConfigurationUpdate:    flinkUpdate,
+				ZeppelinApplicationConfigurationUpdate: zeppelinUpdate,
 				EnvironmentPropertyUpdates:             propertyGroupsUpdate,
 				ApplicationSnapshotConfigurationUpdate: snapshotUpdate,
+				ApplicationCodeConfigurationUpdate:     codeConfigUpdate,
 			},
 		}
 	}
@@ -1341,19 +2474,97 @@ func createApplicationV2UpdateOpts(d *schema.ResourceData) (*kinesisanalyticsv2.
 	return applicationUpdate, nil
 }
 
+// createKinesisAnalyticsV2ZeppelinUpdateOpts diffs monitoring_configuration,
+// catalog_configuration, and deploy_as_application_configuration
+// independently (custom_artifacts_configuration is handled separately above,
+// via ApplicationCodeConfigurationUpdate) so changing one doesn't force a
+// re-diff of the others.
+func createKinesisAnalyticsV2ZeppelinUpdateOpts(d *schema.ResourceData) *kinesisanalyticsv2.ZeppelinApplicationConfigurationUpdate {
+	var zeppelinUpdate *kinesisanalyticsv2.ZeppelinApplicationConfigurationUpdate
+	var monitoringUpdate *kinesisanalyticsv2.ZeppelinMonitoringConfigurationUpdate
+	var catalogUpdate *kinesisanalyticsv2.CatalogConfigurationUpdate
+	var deployUpdate *kinesisanalyticsv2.DeployAsApplicationConfigurationUpdate
+
+	// zeppelin_application_configuration is itself Optional, so removing the
+	// whole block is a valid transition that leaves this list empty even
+	// though the dotted sub-path HasChange checks below fired.
+	zcList := d.Get("zeppelin_application_configuration").([]interface{})
+	if len(zcList) == 0 {
+		return nil
+	}
+
+	if d.HasChange("zeppelin_application_configuration.0.monitoring_configuration") {
+		zc := zcList[0].(map[string]interface{})
+		if v := zc["monitoring_configuration"].([]interface{}); len(v) > 0 {
+			mc := v[0].(map[string]interface{})
+			monitoringUpdate = &kinesisanalyticsv2.ZeppelinMonitoringConfigurationUpdate{
+				LogLevelUpdate: aws.String(mc["log_level"].(string)),
+			}
+		}
+	}
+
+	if d.HasChange("zeppelin_application_configuration.0.catalog_configuration") {
+		zc := zcList[0].(map[string]interface{})
+		if v := zc["catalog_configuration"].([]interface{}); len(v) > 0 {
+			cc := v[0].(map[string]interface{})
+			if gc := cc["glue_data_catalog_configuration"].([]interface{}); len(gc) > 0 {
+				gcL := gc[0].(map[string]interface{})
+				catalogUpdate = &kinesisanalyticsv2.CatalogConfigurationUpdate{
+					GlueDataCatalogConfigurationUpdate: &kinesisanalyticsv2.GlueDataCatalogConfigurationUpdate{
+						DatabaseARNUpdate: aws.String(gcL["database_arn"].(string)),
+					},
+				}
+			}
+		}
+	}
+
+	if d.HasChange("zeppelin_application_configuration.0.deploy_as_application_configuration") {
+		zc := zcList[0].(map[string]interface{})
+		if v := zc["deploy_as_application_configuration"].([]interface{}); len(v) > 0 {
+			dc := v[0].(map[string]interface{})
+			if sc := dc["s3_content_location"].([]interface{}); len(sc) > 0 {
+				scL := sc[0].(map[string]interface{})
+				s3Update := &kinesisanalyticsv2.S3ContentBaseLocationUpdate{
+					BucketARNUpdate: aws.String(scL["bucket_arn"].(string)),
+				}
+				if bp, ok := scL["base_path"]; ok && bp.(string) != "" {
+					s3Update.BasePathUpdate = aws.String(bp.(string))
+				}
+				deployUpdate = &kinesisanalyticsv2.DeployAsApplicationConfigurationUpdate{
+					S3ContentLocationUpdate: s3Update,
+				}
+			}
+		}
+	}
+
+	if monitoringUpdate != nil || catalogUpdate != nil || deployUpdate != nil {
+		zeppelinUpdate = &kinesisanalyticsv2.ZeppelinApplicationConfigurationUpdate{
+			MonitoringConfigurationUpdate:          monitoringUpdate,
+			CatalogConfigurationUpdate:             catalogUpdate,
+			DeployAsApplicationConfigurationUpdate: deployUpdate,
+		}
+	}
+	return zeppelinUpdate
+}
+
 func createKinesisAnalyticsV2SqlUpdateOpts(d *schema.ResourceData) *kinesisanalyticsv2.SqlApplicationConfigurationUpdate {
 	var sqlUpdate *kinesisanalyticsv2.SqlApplicationConfigurationUpdate
 	var inputsUpdate []*kinesisanalyticsv2.InputUpdate
 	var outputsUpdate []*kinesisanalyticsv2.OutputUpdate
 	var referenceDataUpdate []*kinesisanalyticsv2.ReferenceDataSourceUpdate
 
-	sc := d.Get("sql_application_configuration").([]interface{})[0].(map[string]interface{})
+	scList := d.Get("sql_application_configuration").([]interface{})
+	sc := map[string]interface{}{}
+	if len(scList) > 0 {
+		sc = scList[0].(map[string]interface{})
+	}
 	oldConfigIfc, _ := d.GetChange("sql_application_configuration")
 	oldConfig := oldConfigIfc.([]interface{})
-	var hasOldInputs, hasOldOutputs bool
+	var hasOldInputs, hasOldOutputs, hasOldReferenceDataSources bool
 	if len(oldConfig) > 0 {
 		hasOldInputs = len(oldConfig[0].(map[string]interface{})["inputs"].([]interface{})) > 0
 		hasOldOutputs = len(oldConfig[0].(map[string]interface{})["outputs"].([]interface{})) > 0
+		hasOldReferenceDataSources = len(oldConfig[0].(map[string]interface{})["reference_data_sources"].([]interface{})) > 0
 	}
 	if hasOldInputs {
 		if iConf, ok := sc["inputs"].([]interface{}); ok && len(iConf) > 0 {
@@ -1365,30 +2576,35 @@ func createKinesisAnalyticsV2SqlUpdateOpts(d *schema.ResourceData) *kinesisanaly
 			outputsUpdate = []*kinesisanalyticsv2.OutputUpdate{expandKinesisAnalyticsV2OutputUpdate(oConf[0].(map[string]interface{}))}
 		}
 	}
-	rConf := sc["reference_data_sources"].([]interface{})
-	for _, rd := range rConf {
-		rdL := rd.(map[string]interface{})
-		rdsu := &kinesisanalyticsv2.ReferenceDataSourceUpdate{
-			ReferenceId:     aws.String(rdL["id"].(string)),
-			TableNameUpdate: aws.String(rdL["table_name"].(string)),
-		}
+	// A reference data source that didn't previously exist is handled by
+	// AddApplicationReferenceDataSource in resourceAwsKinesisAnalyticsV2ApplicationUpdate
+	// instead, since it has no ReferenceId yet for ReferenceDataSourceUpdate to target.
+	if hasOldReferenceDataSources {
+		rConf := sc["reference_data_sources"].([]interface{})
+		for _, rd := range rConf {
+			rdL := rd.(map[string]interface{})
+			rdsu := &kinesisanalyticsv2.ReferenceDataSourceUpdate{
+				ReferenceId:     aws.String(rdL["id"].(string)),
+				TableNameUpdate: aws.String(rdL["table_name"].(string)),
+			}
 
-		if v := rdL["s3"].([]interface{}); len(v) > 0 {
-			vL := v[0].(map[string]interface{})
-			s3rdsu := &kinesisanalyticsv2.S3ReferenceDataSourceUpdate{
-				BucketARNUpdate: aws.String(vL["bucket_arn"].(string)),
-				FileKeyUpdate:   aws.String(vL["file_key"].(string)),
+			if v := rdL["s3"].([]interface{}); len(v) > 0 {
+				vL := v[0].(map[string]interface{})
+				s3rdsu := &kinesisanalyticsv2.S3ReferenceDataSourceUpdate{
+					BucketARNUpdate: aws.String(vL["bucket_arn"].(string)),
+					FileKeyUpdate:   aws.String(vL["file_key"].(string)),
+				}
+				rdsu.S3ReferenceDataSourceUpdate = s3rdsu
 			}
-			rdsu.S3ReferenceDataSourceUpdate = s3rdsu
-		}
 
-		if v := rdL["schema"].([]interface{}); len(v) > 0 {
-			vL := v[0].(map[string]interface{})
-			ss := expandKinesisAnalyticsV2SourceSchema(vL)
-			rdsu.ReferenceSchemaUpdate = ss
-		}
+			if v := rdL["schema"].([]interface{}); len(v) > 0 {
+				vL := v[0].(map[string]interface{})
+				ss := expandKinesisAnalyticsV2SourceSchema(vL)
+				rdsu.ReferenceSchemaUpdate = ss
+			}
 
-		referenceDataUpdate = append(referenceDataUpdate, rdsu)
+			referenceDataUpdate = append(referenceDataUpdate, rdsu)
+		}
 	}
 	if inputsUpdate != nil || outputsUpdate != nil || referenceDataUpdate != nil {
 		sqlUpdate = &kinesisanalyticsv2.SqlApplicationConfigurationUpdate{
@@ -1407,7 +2623,7 @@ func createKinesisAnalyticsFlinkUpdateOpts(d *schema.ResourceData) *kinesisanaly
 	var parallelismUpdate *kinesisanalyticsv2.ParallelismConfigurationUpdate
 	if d.HasChange("flink_application_configuration") {
 		fc := d.Get("flink_application_configuration").([]interface{})[0].(map[string]interface{})
-		cpConf := fc["checkpoint_configuration"].(*schema.Set)
+		cpConf := fc["checkpoint_configuration"].([]interface{})
 		checkpointConfig := expandCheckpointConfiguration(cpConf)
 		checkpointUpdate = &kinesisanalyticsv2.CheckpointConfigurationUpdate{
 			CheckpointIntervalUpdate:         checkpointConfig.CheckpointInterval,
@@ -1415,14 +2631,14 @@ func createKinesisAnalyticsFlinkUpdateOpts(d *schema.ResourceData) *kinesisanaly
 			ConfigurationTypeUpdate:          checkpointConfig.ConfigurationType,
 			MinPauseBetweenCheckpointsUpdate: checkpointConfig.MinPauseBetweenCheckpoints,
 		}
-		montConf := fc["monitoring_configuration"].(*schema.Set)
+		montConf := fc["monitoring_configuration"].([]interface{})
 		monitoringConfig := expandMonitoringConfiguration(montConf)
 		monitoringUpdate = &kinesisanalyticsv2.MonitoringConfigurationUpdate{
 			ConfigurationTypeUpdate: monitoringConfig.ConfigurationType,
 			LogLevelUpdate:          monitoringConfig.LogLevel,
 			MetricsLevelUpdate:      monitoringConfig.MetricsLevel,
 		}
-		paraConf := fc["parallelism_configuration"].(*schema.Set)
+		paraConf := fc["parallelism_configuration"].([]interface{})
 		parallelismConfig := expandParallelismConfiguration(paraConf)
 		parallelismUpdate = &kinesisanalyticsv2.ParallelismConfigurationUpdate{
 			AutoScalingEnabledUpdate: parallelismConfig.AutoScalingEnabled,
@@ -1442,14 +2658,14 @@ func createKinesisAnalyticsFlinkUpdateOpts(d *schema.ResourceData) *kinesisanaly
 	return flinkUpdate
 }
 
-func expandCheckpointConfiguration(config *schema.Set) *kinesisanalyticsv2.CheckpointConfiguration {
+func expandCheckpointConfiguration(config []interface{}) *kinesisanalyticsv2.CheckpointConfiguration {
 	var checkpointingEnabled *bool
 	var checkpointInterval *int64
 	var configurationType *string
 	var checkpointMinPause *int64
 
-	for _, v := range config.List() {
-		m := v.(map[string]interface{})
+	if len(config) > 0 {
+		m := config[0].(map[string]interface{})
 		if interval, ok := m["checkpoint_interval"]; ok {
 			checkpointInterval = aws.Int64(int64(interval.(int)))
 		}
@@ -1471,13 +2687,13 @@ func expandCheckpointConfiguration(config *schema.Set) *kinesisanalyticsv2.Check
 	}
 }
 
-func expandMonitoringConfiguration(config *schema.Set) *kinesisanalyticsv2.MonitoringConfiguration {
+func expandMonitoringConfiguration(config []interface{}) *kinesisanalyticsv2.MonitoringConfiguration {
 	var configurationType *string
 	var logLevel *string
 	var metricsLevel *string
 
-	for _, v := range config.List() {
-		m := v.(map[string]interface{})
+	if len(config) > 0 {
+		m := config[0].(map[string]interface{})
 		if confType, ok := m["configuration_type"]; ok {
 			configurationType = aws.String(confType.(string))
 		}
@@ -1495,14 +2711,14 @@ func expandMonitoringConfiguration(config *schema.Set) *kinesisanalyticsv2.Monit
 	}
 }
 
-func expandParallelismConfiguration(config *schema.Set) *kinesisanalyticsv2.ParallelismConfiguration {
+func expandParallelismConfiguration(config []interface{}) *kinesisanalyticsv2.ParallelismConfiguration {
 	var autoscalingEnabled *bool
 	var configurationType *string
 	var parallelism *int64
 	var parallelismPerKPU *int64
 
-	for _, v := range config.List() {
-		m := v.(map[string]interface{})
+	if len(config) > 0 {
+		m := config[0].(map[string]interface{})
 		if aEnabled, ok := m["autoscaling_enabled"]; ok {
 			autoscalingEnabled = aws.Bool(aEnabled.(bool))
 		}
@@ -1676,10 +2892,10 @@ func expandKinesisAnalyticsV2OutputUpdate(vL map[string]interface{}) *kinesisana
 	return outputUpdate
 }
 
-func expandKinesisAnalyticsV2CloudwatchLoggingOptionUpdate(clo map[string]interface{}) *kinesisanalyticsv2.CloudWatchLoggingOptionUpdate {
+func expandKinesisAnalyticsV2CloudwatchLoggingOptionUpdate(meta interface{}, clo map[string]interface{}) *kinesisanalyticsv2.CloudWatchLoggingOptionUpdate {
 	cloudwatchLoggingOption := &kinesisanalyticsv2.CloudWatchLoggingOptionUpdate{
 		CloudWatchLoggingOptionId: aws.String(clo["id"].(string)),
-		LogStreamARNUpdate:        aws.String(clo["log_stream_arn"].(string)),
+		LogStreamARNUpdate:        aws.String(resolveKinesisAnalyticsV2LogStreamArn(meta, clo)),
 	}
 	return cloudwatchLoggingOption
 }
@@ -1712,13 +2928,52 @@ func expandKinesisAnalayticsSqlApplicationConfiguration(appConfig map[string]int
 func expandKinesisAnalyticsFlinkApplicationConfiguration(appConfig map[string]interface{}) *kinesisanalyticsv2.FlinkApplicationConfiguration {
 	flinkApplicationConfiguration := &kinesisanalyticsv2.FlinkApplicationConfiguration{}
 
-	flinkApplicationConfiguration.CheckpointConfiguration = expandCheckpointConfiguration(appConfig["checkpoint_configuration"].(*schema.Set))
-	flinkApplicationConfiguration.MonitoringConfiguration = expandMonitoringConfiguration(appConfig["monitoring_configuration"].(*schema.Set))
-	flinkApplicationConfiguration.ParallelismConfiguration = expandParallelismConfiguration(appConfig["parallelism_configuration"].(*schema.Set))
+	flinkApplicationConfiguration.CheckpointConfiguration = expandCheckpointConfiguration(appConfig["checkpoint_configuration"].([]interface{}))
+	flinkApplicationConfiguration.MonitoringConfiguration = expandMonitoringConfiguration(appConfig["monitoring_configuration"].([]interface{}))
+	flinkApplicationConfiguration.ParallelismConfiguration = expandParallelismConfiguration(appConfig["parallelism_configuration"].([]interface{}))
 
 	return flinkApplicationConfiguration
 }
 
+func expandKinesisAnalyticsV2ZeppelinApplicationConfiguration(appConfig map[string]interface{}) *kinesisanalyticsv2.ZeppelinApplicationConfiguration {
+	zeppelinApplicationConfiguration := &kinesisanalyticsv2.ZeppelinApplicationConfiguration{}
+
+	if v := appConfig["monitoring_configuration"].([]interface{}); len(v) > 0 {
+		mc := v[0].(map[string]interface{})
+		zeppelinApplicationConfiguration.MonitoringConfiguration = &kinesisanalyticsv2.ZeppelinMonitoringConfiguration{
+			LogLevel: aws.String(mc["log_level"].(string)),
+		}
+	}
+
+	if v := appConfig["catalog_configuration"].([]interface{}); len(v) > 0 {
+		cc := v[0].(map[string]interface{})
+		if gc := cc["glue_data_catalog_configuration"].([]interface{}); len(gc) > 0 {
+			gcL := gc[0].(map[string]interface{})
+			zeppelinApplicationConfiguration.CatalogConfiguration = &kinesisanalyticsv2.CatalogConfiguration{
+				GlueDataCatalogConfiguration: &kinesisanalyticsv2.GlueDataCatalogConfiguration{
+					DatabaseARN: aws.String(gcL["database_arn"].(string)),
+				},
+			}
+		}
+	}
+
+	if v := appConfig["deploy_as_application_configuration"].([]interface{}); len(v) > 0 {
+		dc := v[0].(map[string]interface{})
+		sc := dc["s3_content_location"].([]interface{})[0].(map[string]interface{})
+		s3Location := &kinesisanalyticsv2.S3ContentBaseLocation{
+			BucketARN: aws.String(sc["bucket_arn"].(string)),
+		}
+		if bp, ok := sc["base_path"]; ok && bp.(string) != "" {
+			s3Location.BasePath = aws.String(bp.(string))
+		}
+		zeppelinApplicationConfiguration.DeployAsApplicationConfiguration = &kinesisanalyticsv2.DeployAsApplicationConfiguration{
+			S3ContentLocation: s3Location,
+		}
+	}
+
+	return zeppelinApplicationConfiguration
+}
+
 func flattenSqlApplicationConfigurationDescription(sqlApplicationConfig *kinesisanalyticsv2.SqlApplicationConfigurationDescription) []interface{} {
 	ret := map[string]interface{}{}
 
@@ -1732,42 +2987,177 @@ func flattenSqlApplicationConfigurationDescription(sqlApplicationConfig *kinesis
 	return []interface{}{ret}
 }
 
-func flattenFlinkApplicationConfigurationDescription(flinkApplicationConfig *kinesisanalyticsv2.FlinkApplicationConfigurationDescription) []interface{} {
+func flattenFlinkApplicationConfigurationDescription(flinkApplicationConfig *kinesisanalyticsv2.FlinkApplicationConfigurationDescription, codeConfig *kinesisanalyticsv2.ApplicationCodeConfigurationDescription) []interface{} {
 	if flinkApplicationConfig == nil {
 		return []interface{}{}
 	}
 	return []interface{}{map[string]interface{}{
-		"checkpoint_configuration":  flattenCheckpointConfiguration(flinkApplicationConfig.CheckpointConfigurationDescription),
-		"monitoring_configuration":  flattenMonitoringConfiguration(flinkApplicationConfig.MonitoringConfigurationDescription),
-		"parallelism_configuration": flattenParallelismConfiguration(flinkApplicationConfig.ParallelismConfigurationDescription),
+		"checkpoint_configuration":       flattenCheckpointConfiguration(flinkApplicationConfig.CheckpointConfigurationDescription),
+		"monitoring_configuration":       flattenMonitoringConfiguration(flinkApplicationConfig.MonitoringConfigurationDescription),
+		"parallelism_configuration":      flattenParallelismConfiguration(flinkApplicationConfig.ParallelismConfigurationDescription),
+		"application_code_configuration": flattenKinesisAnalyticsV2ApplicationCodeConfiguration(codeConfig),
 	},
 	}
 }
 
-func flattenCheckpointConfiguration(checkpointConfiguration *kinesisanalyticsv2.CheckpointConfigurationDescription) *schema.Set {
-	return schema.NewSet(resourceCheckpointConfigurationHash, []interface{}{map[string]interface{}{
+func flattenZeppelinApplicationConfigurationDescription(zeppelinApplicationConfig *kinesisanalyticsv2.ZeppelinApplicationConfigurationDescription, codeConfig *kinesisanalyticsv2.ApplicationCodeConfigurationDescription) []interface{} {
+	if zeppelinApplicationConfig == nil {
+		return []interface{}{}
+	}
+
+	ret := map[string]interface{}{}
+
+	if zeppelinApplicationConfig.MonitoringConfigurationDescription != nil {
+		ret["monitoring_configuration"] = []interface{}{
+			map[string]interface{}{
+				"log_level": aws.StringValue(zeppelinApplicationConfig.MonitoringConfigurationDescription.LogLevel),
+			},
+		}
+	}
+
+	if cc := zeppelinApplicationConfig.CatalogConfigurationDescription; cc != nil && cc.GlueDataCatalogConfigurationDescription != nil {
+		ret["catalog_configuration"] = []interface{}{
+			map[string]interface{}{
+				"glue_data_catalog_configuration": []interface{}{
+					map[string]interface{}{
+						"database_arn": aws.StringValue(cc.GlueDataCatalogConfigurationDescription.DatabaseARN),
+					},
+				},
+			},
+		}
+	}
+
+	if dc := zeppelinApplicationConfig.DeployAsApplicationConfigurationDescription; dc != nil && dc.S3ContentLocationDescription != nil {
+		ret["deploy_as_application_configuration"] = []interface{}{
+			map[string]interface{}{
+				"s3_content_location": []interface{}{
+					map[string]interface{}{
+						"bucket_arn": aws.StringValue(dc.S3ContentLocationDescription.BucketARN),
+						"base_path":  aws.StringValue(dc.S3ContentLocationDescription.BasePath),
+					},
+				},
+			},
+		}
+	}
+
+	if codeConfig != nil && len(codeConfig.CustomArtifactsConfigurationDescription) > 0 {
+		ret["custom_artifacts_configuration"] = flattenKinesisAnalyticsV2CustomArtifacts(codeConfig.CustomArtifactsConfigurationDescription)
+	}
+
+	return []interface{}{ret}
+}
+
+func expandKinesisAnalyticsV2CustomArtifactsConfiguration(appConfig map[string]interface{}) []*kinesisanalyticsv2.CustomArtifactConfiguration {
+	acc := appConfig["application_code_configuration"].([]interface{})
+	if len(acc) == 0 {
+		return nil
+	}
+	accL := acc[0].(map[string]interface{})
+	return expandKinesisAnalyticsV2CustomArtifacts(accL["custom_artifact_configuration"].([]interface{}))
+}
+
+func expandKinesisAnalyticsV2CustomArtifacts(cac []interface{}) []*kinesisanalyticsv2.CustomArtifactConfiguration {
+	var customArtifacts []*kinesisanalyticsv2.CustomArtifactConfiguration
+	for _, c := range cac {
+		cL := c.(map[string]interface{})
+		artifact := &kinesisanalyticsv2.CustomArtifactConfiguration{
+			ArtifactType: aws.String(cL["artifact_type"].(string)),
+		}
+
+		if v := cL["s3_content_location"].([]interface{}); len(v) > 0 {
+			sL := v[0].(map[string]interface{})
+			s3Location := &kinesisanalyticsv2.S3ContentLocation{
+				BucketARN: aws.String(sL["bucket_arn"].(string)),
+				FileKey:   aws.String(sL["file_key"].(string)),
+			}
+			if ov, ok := sL["object_version"]; ok && ov.(string) != "" {
+				s3Location.ObjectVersion = aws.String(ov.(string))
+			}
+			artifact.S3ContentLocation = s3Location
+		}
+
+		if v := cL["maven_reference"].([]interface{}); len(v) > 0 {
+			mL := v[0].(map[string]interface{})
+			artifact.MavenReference = &kinesisanalyticsv2.MavenReference{
+				GroupId:    aws.String(mL["group_id"].(string)),
+				ArtifactId: aws.String(mL["artifact_id"].(string)),
+				Version:    aws.String(mL["version"].(string)),
+			}
+		}
+
+		customArtifacts = append(customArtifacts, artifact)
+	}
+	return customArtifacts
+}
+
+func flattenKinesisAnalyticsV2ApplicationCodeConfiguration(codeConfig *kinesisanalyticsv2.ApplicationCodeConfigurationDescription) []interface{} {
+	if codeConfig == nil || len(codeConfig.CustomArtifactsConfigurationDescription) == 0 {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"custom_artifact_configuration": flattenKinesisAnalyticsV2CustomArtifacts(codeConfig.CustomArtifactsConfigurationDescription),
+		},
+	}
+}
+
+func flattenKinesisAnalyticsV2CustomArtifacts(descriptions []*kinesisanalyticsv2.CustomArtifactConfigurationDescription) []interface{} {
+	customArtifacts := make([]interface{}, 0, len(descriptions))
+	for _, c := range descriptions {
+		artifact := map[string]interface{}{
+			"artifact_type": aws.StringValue(c.ArtifactType),
+		}
+
+		if c.S3ContentLocationDescription != nil {
+			artifact["s3_content_location"] = []interface{}{
+				map[string]interface{}{
+					"bucket_arn":     aws.StringValue(c.S3ContentLocationDescription.BucketARN),
+					"file_key":       aws.StringValue(c.S3ContentLocationDescription.FileKey),
+					"object_version": aws.StringValue(c.S3ContentLocationDescription.ObjectVersion),
+				},
+			}
+		}
+
+		if c.MavenReferenceDescription != nil {
+			artifact["maven_reference"] = []interface{}{
+				map[string]interface{}{
+					"group_id":    aws.StringValue(c.MavenReferenceDescription.GroupId),
+					"artifact_id": aws.StringValue(c.MavenReferenceDescription.ArtifactId),
+					"version":     aws.StringValue(c.MavenReferenceDescription.Version),
+				},
+			}
+		}
+
+		customArtifacts = append(customArtifacts, artifact)
+	}
+	return customArtifacts
+}
+
+func flattenCheckpointConfiguration(checkpointConfiguration *kinesisanalyticsv2.CheckpointConfigurationDescription) []interface{} {
+	return []interface{}{map[string]interface{}{
 		"checkpoint_interval":           aws.Int64Value(checkpointConfiguration.CheckpointInterval),
 		"checkpointing_enabled":         aws.BoolValue(checkpointConfiguration.CheckpointingEnabled),
 		"configuration_type":            aws.StringValue(checkpointConfiguration.ConfigurationType),
 		"min_pause_between_checkpoints": aws.Int64Value(checkpointConfiguration.MinPauseBetweenCheckpoints),
-	}})
+	}}
 }
 
-func flattenMonitoringConfiguration(monitoringConfiguration *kinesisanalyticsv2.MonitoringConfigurationDescription) *schema.Set {
-	return schema.NewSet(resourceMonitoringConfigurationHash, []interface{}{map[string]interface{}{
+func flattenMonitoringConfiguration(monitoringConfiguration *kinesisanalyticsv2.MonitoringConfigurationDescription) []interface{} {
+	return []interface{}{map[string]interface{}{
 		"configuration_type": aws.StringValue(monitoringConfiguration.ConfigurationType),
 		"log_level":          aws.StringValue(monitoringConfiguration.LogLevel),
 		"metrics_level":      aws.StringValue(monitoringConfiguration.MetricsLevel),
-	}})
+	}}
 }
 
-func flattenParallelismConfiguration(parallelismConfiguration *kinesisanalyticsv2.ParallelismConfigurationDescription) *schema.Set {
-	return schema.NewSet(resourceParallelismConfigurationHash, []interface{}{map[string]interface{}{
+func flattenParallelismConfiguration(parallelismConfiguration *kinesisanalyticsv2.ParallelismConfigurationDescription) []interface{} {
+	return []interface{}{map[string]interface{}{
 		"autoscaling_enabled": aws.BoolValue(parallelismConfiguration.AutoScalingEnabled),
 		"configuration_type":  aws.StringValue(parallelismConfiguration.ConfigurationType),
 		"parallelism":         aws.Int64Value(parallelismConfiguration.Parallelism),
 		"parallelism_per_kpu": aws.Int64Value(parallelismConfiguration.ParallelismPerKPU),
-	}})
+	}}
 }
 
 func flattenKinesisAnalyticsPropertyGroups(propGroups []*kinesisanalyticsv2.PropertyGroup) []interface{} {
@@ -1790,7 +3180,10 @@ func flattenKinesisAnalyticsV2CloudwatchLoggingOptions(options []*kinesisanalyti
 	s := []interface{}{}
 	for _, v := range options {
 		option := map[string]interface{}{
-			"id":             aws.StringValue(v.CloudWatchLoggingOptionId),
+			"id": aws.StringValue(v.CloudWatchLoggingOptionId),
+			// Every description the API returns represents a currently
+			// attached (i.e. enabled) logging option.
+			"enabled":        true,
 			"log_stream_arn": aws.StringValue(v.LogStreamARN),
 		}
 		s = append(s, option)
@@ -2059,6 +3452,12 @@ func waitForDeleteKinesisAnalyticsV2Application(conn *kinesisanalyticsv2.Kinesis
 	stateConf := resource.StateChangeConf{
 		Pending: []string{
 			kinesisanalyticsv2.ApplicationStatusRunning,
+			// Delete stops a RUNNING application first, so it's not
+			// unusual for DeleteApplication to land while the application
+			// is still settling into READY from that stop, or finishing an
+			// UPDATING transition from a prior apply.
+			kinesisanalyticsv2.ApplicationStatusReady,
+			kinesisanalyticsv2.ApplicationStatusUpdating,
 			kinesisanalyticsv2.ApplicationStatusDeleting,
 		},
 		Target:  []string{""},
@@ -2098,59 +3497,7 @@ func runtimeIsFlink(runtime string) bool {
 		runtime == kinesisanalyticsv2.RuntimeEnvironmentFlink18
 }
 
-func resourceCheckpointConfigurationHash(v interface{}) int {
-	var buf bytes.Buffer
-	m := v.(map[string]interface{})
-
-	if v, ok := m["checkpoint_interval"]; ok {
-		buf.WriteString(fmt.Sprintf("%d-", v.(int64)))
-	}
-	if v, ok := m["checkpointing_enabled"]; ok {
-		buf.WriteString(fmt.Sprintf("%t-", v.(bool)))
-	}
-	if v, ok := m["configuration_type"]; ok {
-		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
-	}
-	if v, ok := m["min_pause_between_checkpoints"]; ok {
-		buf.WriteString(fmt.Sprintf("%d-", v.(int64)))
-	}
-
-	return hashcode.String(buf.String())
-}
-
-func resourceMonitoringConfigurationHash(v interface{}) int {
-	var buf bytes.Buffer
-	m := v.(map[string]interface{})
-
-	if v, ok := m["log_level"]; ok {
-		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
-	}
-	if v, ok := m["configuration_type"]; ok {
-		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
-	}
-	if v, ok := m["metrics_level"]; ok {
-		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
-	}
-
-	return hashcode.String(buf.String())
+func runtimeIsZeppelin(runtime string) bool {
+	return strings.HasPrefix(runtime, "ZEPPELIN")
 }
 
-func resourceParallelismConfigurationHash(v interface{}) int {
-	var buf bytes.Buffer
-	m := v.(map[string]interface{})
-
-	if v, ok := m["autoscaling_enabled"]; ok {
-		buf.WriteString(fmt.Sprintf("%t-", v.(bool)))
-	}
-	if v, ok := m["parallelism"]; ok {
-		buf.WriteString(fmt.Sprintf("%d-", v.(int64)))
-	}
-	if v, ok := m["parallelism_per_kpu"]; ok {
-		buf.WriteString(fmt.Sprintf("%d-", v.(int64)))
-	}
-	if v, ok := m["configuration_type"]; ok {
-		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
-	}
-
-	return hashcode.String(buf.String())
-}
\ No newline at end of file
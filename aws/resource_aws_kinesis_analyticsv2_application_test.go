@@ -0,0 +1,188 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesisanalyticsv2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccAWSKinesisAnalyticsV2Application_vpcConfiguration(t *testing.T) {
+	var v kinesisanalyticsv2.ApplicationDetail
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_kinesisanalyticsv2_application.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSKinesisAnalyticsV2ApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				// No vpc_configuration block yet.
+				Config: testAccAWSKinesisAnalyticsV2ApplicationConfig_flinkBase(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSKinesisAnalyticsV2ApplicationExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "vpc_configuration.#", "0"),
+				),
+			},
+			{
+				// Toggle vpc_configuration on.
+				Config: testAccAWSKinesisAnalyticsV2ApplicationConfig_vpcConfiguration(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSKinesisAnalyticsV2ApplicationExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "vpc_configuration.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "vpc_configuration.0.vpc_id", "aws_vpc.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "vpc_configuration.0.subnet_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "vpc_configuration.0.security_group_ids.#", "1"),
+				),
+			},
+			{
+				// Toggle it back off; plan/apply should be clean with no
+				// leftover vpc_configuration.
+				Config: testAccAWSKinesisAnalyticsV2ApplicationConfig_flinkBase(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSKinesisAnalyticsV2ApplicationExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "vpc_configuration.#", "0"),
+				),
+			},
+			{
+				Config:   testAccAWSKinesisAnalyticsV2ApplicationConfig_flinkBase(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAWSKinesisAnalyticsV2ApplicationExists(resourceName string, v *kinesisanalyticsv2.ApplicationDetail) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).kinesisanalyticsv2conn
+		resp, err := conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+			ApplicationName: aws.String(rs.Primary.Attributes["name"]),
+		})
+		if err != nil {
+			return err
+		}
+
+		*v = *resp.ApplicationDetail
+		return nil
+	}
+}
+
+func testAccCheckAWSKinesisAnalyticsV2ApplicationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).kinesisanalyticsv2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_kinesisanalyticsv2_application" {
+			continue
+		}
+
+		_, err := conn.DescribeApplication(&kinesisanalyticsv2.DescribeApplicationInput{
+			ApplicationName: aws.String(rs.Primary.Attributes["name"]),
+		})
+		if isAWSErr(err, kinesisanalyticsv2.ErrCodeResourceNotFoundException, "") {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("Kinesis Analytics Application %s still exists", rs.Primary.Attributes["name"])
+	}
+
+	return nil
+}
+
+func testAccAWSKinesisAnalyticsV2ApplicationConfig_iamRole(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action = "sts:AssumeRole"
+      Effect = "Allow"
+      Principal = {
+        Service = "kinesisanalytics.${data.aws_partition.current.dns_suffix}"
+      }
+    }]
+  })
+}
+`, rName)
+}
+
+func testAccAWSKinesisAnalyticsV2ApplicationConfig_flinkBase(rName string) string {
+	return testAccAWSKinesisAnalyticsV2ApplicationConfig_iamRole(rName) + fmt.Sprintf(`
+resource "aws_kinesisanalyticsv2_application" "test" {
+  name                    = %[1]q
+  runtime                 = "FLINK-1_8"
+  service_execution_role  = aws_iam_role.test.arn
+  code_content_type       = "plain_text"
+  code                    = "-- test application"
+}
+`, rName)
+}
+
+func testAccAWSKinesisAnalyticsV2ApplicationConfig_vpcConfiguration(rName string) string {
+	return testAccAWSKinesisAnalyticsV2ApplicationConfig_iamRole(rName) + fmt.Sprintf(`
+data "aws_availability_zones" "available" {
+  state = "available"
+
+  filter {
+    name   = "opt-in-status"
+    values = ["opt-in-not-required"]
+  }
+}
+
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_subnet" "test" {
+  vpc_id            = aws_vpc.test.id
+  cidr_block        = "10.0.0.0/24"
+  availability_zone = data.aws_availability_zones.available.names[0]
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_security_group" "test" {
+  name   = %[1]q
+  vpc_id = aws_vpc.test.id
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_kinesisanalyticsv2_application" "test" {
+  name                    = %[1]q
+  runtime                 = "FLINK-1_8"
+  service_execution_role  = aws_iam_role.test.arn
+  code_content_type       = "plain_text"
+  code                    = "-- test application"
+
+  vpc_configuration {
+    subnet_ids         = [aws_subnet.test.id]
+    security_group_ids = [aws_security_group.test.id]
+  }
+}
+`, rName)
+}
@@ -0,0 +1,177 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesisanalyticsv2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceAwsKinesisAnalyticsV2ApplicationSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsKinesisAnalyticsV2ApplicationSnapshotCreate,
+		Read:   resourceAwsKinesisAnalyticsV2ApplicationSnapshotRead,
+		Delete: resourceAwsKinesisAnalyticsV2ApplicationSnapshotDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"application_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"snapshot_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"application_version_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"snapshot_creation_timestamp": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// Snapshots have no ARN of their own in the KDA v2 API, and
+			// TagResource/UntagResource/ListTagsForResource only accept the
+			// owning application's ARN, so there's no distinct resource here
+			// to key a "tags" attribute off of.
+		},
+	}
+}
+
+func resourceAwsKinesisAnalyticsV2ApplicationSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kinesisanalyticsv2conn
+	applicationName := d.Get("application_name").(string)
+	snapshotName := d.Get("snapshot_name").(string)
+
+	_, err := conn.CreateApplicationSnapshot(&kinesisanalyticsv2.CreateApplicationSnapshotInput{
+		ApplicationName: aws.String(applicationName),
+		SnapshotName:    aws.String(snapshotName),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating Kinesis Analytics Application Snapshot (%s/%s): %s", applicationName, snapshotName, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", applicationName, snapshotName))
+
+	stateConf := resource.StateChangeConf{
+		Pending: []string{
+			kinesisanalyticsv2.SnapshotStatusCreating,
+		},
+		Target:  []string{kinesisanalyticsv2.SnapshotStatusReady},
+		Timeout: d.Timeout(schema.TimeoutCreate),
+		Refresh: refreshKinesisAnalyticsV2ApplicationSnapshotStatus(conn, applicationName, snapshotName),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("error waiting for Kinesis Analytics Application Snapshot (%s/%s) to be ready: %s", applicationName, snapshotName, err)
+	}
+
+	return resourceAwsKinesisAnalyticsV2ApplicationSnapshotRead(d, meta)
+}
+
+func resourceAwsKinesisAnalyticsV2ApplicationSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kinesisanalyticsv2conn
+	applicationName := d.Get("application_name").(string)
+	snapshotName := d.Get("snapshot_name").(string)
+
+	resp, err := conn.DescribeApplicationSnapshot(&kinesisanalyticsv2.DescribeApplicationSnapshotInput{
+		ApplicationName: aws.String(applicationName),
+		SnapshotName:    aws.String(snapshotName),
+	})
+	if isAWSErr(err, kinesisanalyticsv2.ErrCodeResourceNotFoundException, "") {
+		log.Printf("[WARN] Kinesis Analytics Application Snapshot (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading Kinesis Analytics Application Snapshot (%s): %s", d.Id(), err)
+	}
+
+	detail := resp.SnapshotDetails
+	d.Set("application_name", applicationName)
+	d.Set("snapshot_name", aws.StringValue(detail.SnapshotName))
+	d.Set("application_version_id", int(aws.Int64Value(detail.ApplicationVersionId)))
+	d.Set("snapshot_creation_timestamp", aws.TimeValue(detail.SnapshotCreationTimestamp).Format(time.RFC3339))
+	d.Set("status", aws.StringValue(detail.SnapshotStatus))
+
+	return nil
+}
+
+func resourceAwsKinesisAnalyticsV2ApplicationSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).kinesisanalyticsv2conn
+	applicationName := d.Get("application_name").(string)
+	snapshotName := d.Get("snapshot_name").(string)
+
+	resp, err := conn.DescribeApplicationSnapshot(&kinesisanalyticsv2.DescribeApplicationSnapshotInput{
+		ApplicationName: aws.String(applicationName),
+		SnapshotName:    aws.String(snapshotName),
+	})
+	if isAWSErr(err, kinesisanalyticsv2.ErrCodeResourceNotFoundException, "") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading Kinesis Analytics Application Snapshot (%s) before delete: %s", d.Id(), err)
+	}
+
+	log.Printf("[DEBUG] Kinesis Analytics Application Snapshot destroy: %v", d.Id())
+	_, err = conn.DeleteApplicationSnapshot(&kinesisanalyticsv2.DeleteApplicationSnapshotInput{
+		ApplicationName:           aws.String(applicationName),
+		SnapshotName:              aws.String(snapshotName),
+		SnapshotCreationTimestamp: resp.SnapshotDetails.SnapshotCreationTimestamp,
+	})
+	if isAWSErr(err, kinesisanalyticsv2.ErrCodeResourceNotFoundException, "") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting Kinesis Analytics Application Snapshot (%s): %s", d.Id(), err)
+	}
+
+	stateConf := resource.StateChangeConf{
+		Pending: []string{
+			kinesisanalyticsv2.SnapshotStatusDeleting,
+		},
+		Target:  []string{""},
+		Timeout: d.Timeout(schema.TimeoutDelete),
+		Refresh: refreshKinesisAnalyticsV2ApplicationSnapshotStatus(conn, applicationName, snapshotName),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("error waiting for Kinesis Analytics Application Snapshot (%s/%s) to be deleted: %s", applicationName, snapshotName, err)
+	}
+
+	return nil
+}
+
+func refreshKinesisAnalyticsV2ApplicationSnapshotStatus(conn *kinesisanalyticsv2.KinesisAnalyticsV2, applicationName, snapshotName string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeApplicationSnapshot(&kinesisanalyticsv2.DescribeApplicationSnapshotInput{
+			ApplicationName: aws.String(applicationName),
+			SnapshotName:    aws.String(snapshotName),
+		})
+		if isAWSErr(err, kinesisanalyticsv2.ErrCodeResourceNotFoundException, "") {
+			return "", "", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.SnapshotDetails, aws.StringValue(resp.SnapshotDetails.SnapshotStatus), nil
+	}
+}